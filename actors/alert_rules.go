@@ -0,0 +1,127 @@
+package actors
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// RuleStore persists the alert rules of the NotificationActor
+type RuleStore interface {
+	LoadRules() ([]*proto.AlertRule, error)
+	SaveRules(rules []*proto.AlertRule) error
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ValidateAlertRule checks a rule, without its notifiers which depend on
+// the NotificationActor
+func ValidateAlertRule(rule *proto.AlertRule) error {
+	if rule == nil || rule.Id == "" {
+		return fmt.Errorf("rule id is empty")
+	}
+	if c := rule.MinConfidence; !finite(float64(c)) || c < 0 || c > 1 {
+		return fmt.Errorf("rule %s: min confidence must be in [0, 1], got %g", rule.Id, c)
+	}
+	if rule.MinCount < 0 {
+		return fmt.Errorf("rule %s: min count must not be negative, got %d", rule.Id, rule.MinCount)
+	}
+	if len(rule.Notifiers) == 0 {
+		return fmt.Errorf("rule %s: no notifier to tell", rule.Id)
+	}
+	for i, w := range rule.Schedule {
+		for _, day := range w.Days {
+			if _, ok := weekdays[strings.ToLower(day)]; !ok {
+				return fmt.Errorf("rule %s: schedule %d: unknown day %q, expected mon, tue, wed, thu, fri, sat or sun", rule.Id, i, day)
+			}
+		}
+		if _, err := parseTimeOfDay(w.Start); err != nil {
+			return fmt.Errorf("rule %s: schedule %d: start: %w", rule.Id, i, err)
+		}
+		if _, err := parseTimeOfDay(w.End); err != nil {
+			return fmt.Errorf("rule %s: schedule %d: end: %w", rule.Id, i, err)
+		}
+	}
+	return nil
+}
+
+// parseTimeOfDay parses HH:MM into the time since midnight
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// matchRule returns the detections of event matching rule at time t, none
+// when the rule doesn't apply to the event. MinCount counts the matching
+// detections of this one event, the events before it don't add up.
+func matchRule(rule *proto.AlertRule, event *proto.DetectionEvent, t time.Time) []*proto.Detection {
+	if rule.Disabled {
+		return nil
+	}
+	if len(rule.CameraIds) > 0 && !slices.Contains(rule.CameraIds, event.CameraId) {
+		return nil
+	}
+	if len(rule.Schedule) > 0 && !slices.ContainsFunc(rule.Schedule, func(w *proto.TimeWindow) bool { return inWindow(w, t) }) {
+		return nil
+	}
+	var matched []*proto.Detection
+	for _, d := range event.Detections {
+		if len(rule.Labels) > 0 && !slices.Contains(rule.Labels, d.Label) {
+			continue
+		}
+		if d.Confidence < rule.MinConfidence {
+			continue
+		}
+		if len(rule.Zones) > 0 && !slices.ContainsFunc(d.Zones, func(zone string) bool { return slices.Contains(rule.Zones, zone) }) {
+			continue
+		}
+		matched = append(matched, d)
+	}
+	if len(matched) < max(int(rule.MinCount), 1) {
+		return nil
+	}
+	return matched
+}
+
+// inWindow tells whether t falls in w. A window spanning midnight belongs
+// to the day it starts, one whose start equals its end spans the whole day.
+func inWindow(w *proto.TimeWindow, t time.Time) bool {
+	// validated when the rule was added
+	start, _ := parseTimeOfDay(w.Start)
+	end, _ := parseTimeOfDay(w.End)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	now := t.Sub(midnight)
+	day := t.Weekday()
+	switch {
+	case start < end:
+		if now < start || now >= end {
+			return false
+		}
+	case start > end:
+		if now < start && now >= end {
+			return false
+		}
+		if now < end {
+			// early hours of a window started the day before
+			day = (day + 6) % 7
+		}
+	}
+	if len(w.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(w.Days, func(d string) bool { return weekdays[strings.ToLower(d)] == day })
+}
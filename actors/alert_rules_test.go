@@ -0,0 +1,107 @@
+package actors
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+func TestInWindow(t *testing.T) {
+	// 2024-01-01 is a Monday
+	at := func(day int, clock string) time.Time {
+		tod, err := parseTimeOfDay(clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC).Add(tod)
+	}
+	office := &proto.TimeWindow{Start: "09:00", End: "17:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}}
+	night := &proto.TimeWindow{Start: "22:00", End: "06:00"}
+	fridayNight := &proto.TimeWindow{Start: "22:00", End: "06:00", Days: []string{"Fri"}}
+	allDay := &proto.TimeWindow{Start: "00:00", End: "00:00", Days: []string{"sat", "sun"}}
+
+	tests := []struct {
+		name   string
+		window *proto.TimeWindow
+		t      time.Time
+		want   bool
+	}{
+		{name: "within the hours and days", window: office, t: at(1, "12:00"), want: true},
+		{name: "start is included", window: office, t: at(1, "09:00"), want: true},
+		{name: "end is excluded", window: office, t: at(1, "17:00"), want: false},
+		{name: "before the start", window: office, t: at(1, "08:59"), want: false},
+		{name: "on another day", window: office, t: at(6, "12:00"), want: false},
+		{name: "before midnight", window: night, t: at(1, "23:00"), want: true},
+		{name: "after midnight", window: night, t: at(2, "05:59"), want: true},
+		{name: "end after midnight is excluded", window: night, t: at(2, "06:00"), want: false},
+		{name: "middle of the day outside of a night window", window: night, t: at(2, "12:00"), want: false},
+		{name: "night window on its day", window: fridayNight, t: at(5, "23:30"), want: true},
+		{name: "early hours belong to the day before", window: fridayNight, t: at(6, "02:00"), want: true},
+		{name: "early hours of the window day belong to the day before", window: fridayNight, t: at(5, "02:00"), want: false},
+		{name: "evening of the day after", window: fridayNight, t: at(6, "23:00"), want: false},
+		{name: "same start and end is the whole day", window: allDay, t: at(7, "13:00"), want: true},
+		{name: "whole day on another day", window: allDay, t: at(1, "13:00"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inWindow(tt.window, tt.t); got != tt.want {
+				t.Errorf("inWindow(%s-%s %v, %s) = %v, want %v", tt.window.Start, tt.window.End, tt.window.Days, tt.t.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAlertRule(t *testing.T) {
+	rule := func(edit func(r *proto.AlertRule)) *proto.AlertRule {
+		r := &proto.AlertRule{Id: "night", Labels: []string{"person"}, MinConfidence: 0.5, Notifiers: []string{"email"}}
+		edit(r)
+		return r
+	}
+	tests := []struct {
+		name    string
+		rule    *proto.AlertRule
+		wantErr bool
+	}{
+		{name: "valid", rule: rule(func(r *proto.AlertRule) {})},
+		{name: "nil", rule: nil, wantErr: true},
+		{name: "no id", rule: rule(func(r *proto.AlertRule) { r.Id = "" }), wantErr: true},
+		{name: "confidence above 1", rule: rule(func(r *proto.AlertRule) { r.MinConfidence = 1.5 }), wantErr: true},
+		{name: "negative confidence", rule: rule(func(r *proto.AlertRule) { r.MinConfidence = -0.1 }), wantErr: true},
+		{name: "NaN confidence", rule: rule(func(r *proto.AlertRule) { r.MinConfidence = float32(math.NaN()) }), wantErr: true},
+		{name: "infinite confidence", rule: rule(func(r *proto.AlertRule) { r.MinConfidence = float32(math.Inf(-1)) }), wantErr: true},
+		{name: "negative count", rule: rule(func(r *proto.AlertRule) { r.MinCount = -1 }), wantErr: true},
+		{name: "no notifier", rule: rule(func(r *proto.AlertRule) { r.Notifiers = nil }), wantErr: true},
+		{
+			name: "schedule",
+			rule: rule(func(r *proto.AlertRule) {
+				r.Schedule = []*proto.TimeWindow{{Start: "22:00", End: "06:00", Days: []string{"Mon", "sat"}}}
+			}),
+		},
+		{
+			name: "unknown day",
+			rule: rule(func(r *proto.AlertRule) {
+				r.Schedule = []*proto.TimeWindow{{Start: "22:00", End: "06:00", Days: []string{"monday"}}}
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid start",
+			rule:    rule(func(r *proto.AlertRule) { r.Schedule = []*proto.TimeWindow{{Start: "25:00", End: "06:00"}} }),
+			wantErr: true,
+		},
+		{
+			name:    "missing end",
+			rule:    rule(func(r *proto.AlertRule) { r.Schedule = []*proto.TimeWindow{{Start: "22:00"}} }),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAlertRule(tt.rule); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAlertRule() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package actors
+
+import (
+	"fmt"
+	"image"
+	"slices"
+	"sort"
+
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/tracking"
+)
+
+// ValidateTripwires checks that tripwires can be applied to a camera
+func ValidateTripwires(tripwires []*proto.Tripwire) error {
+	names := make(map[string]bool, len(tripwires))
+	for _, t := range tripwires {
+		if t.Name == "" {
+			return fmt.Errorf("tripwire name is empty")
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate tripwire %q", t.Name)
+		}
+		names[t.Name] = true
+		if t.A == nil || t.B == nil {
+			return fmt.Errorf("tripwire %q needs two points", t.Name)
+		}
+		for _, p := range []*proto.Point{t.A, t.B} {
+			if !inFrame(p) {
+				return fmt.Errorf("tripwire %q has point (%g, %g) outside of the frame, coordinates are in [0, 1]", t.Name, p.X, p.Y)
+			}
+		}
+		if t.A.X == t.B.X && t.A.Y == t.B.Y {
+			return fmt.Errorf("tripwire %q has the same start and end", t.Name)
+		}
+	}
+	return nil
+}
+
+// line is a tripwire in the pixel coordinates of a frame
+type line struct {
+	name string
+	a, b image.Point
+}
+
+// frameLines scales the tripwires to a frame of the given size
+func frameLines(tripwires []*proto.Tripwire, size image.Point) []line {
+	lines := make([]line, 0, len(tripwires))
+	for _, t := range tripwires {
+		lines = append(lines, line{
+			name: t.Name,
+			a:    image.Pt(int(t.A.X*float32(size.X)), int(t.A.Y*float32(size.Y))),
+			b:    image.Pt(int(t.B.X*float32(size.X)), int(t.B.Y*float32(size.Y))),
+		})
+	}
+	return lines
+}
+
+// cameraAnalytics remembers where the tracks of a camera were on the previous frame
+type cameraAnalytics struct {
+	tracks map[int64]*trackPosition
+}
+
+type trackPosition struct {
+	center image.Point
+	zones  map[string]bool
+}
+
+func newCameraAnalytics() *cameraAnalytics {
+	return &cameraAnalytics{tracks: make(map[int64]*trackPosition)}
+}
+
+// update compares the tracks of a frame with their previous position and
+// returns the tripwire crossings and zone changes. Camera and time are left
+// for the caller to fill.
+func (c *cameraAnalytics) update(tracks, ended []tracking.Track, lines []line, zones []polygon) []*proto.AnalyticsEvent {
+	var events []*proto.AnalyticsEvent
+	event := func(t proto.AnalyticsEventType, name string, tr tracking.Track) {
+		events = append(events, &proto.AnalyticsEvent{Type: t, Name: name, TrackId: tr.ID, Label: tr.Label})
+	}
+
+	// the tracks leave the zones removed from the camera silently, the
+	// AnalyticsActor dropped their counts
+	for _, pos := range c.tracks {
+		for name := range pos.zones {
+			if !slices.ContainsFunc(zones, func(z polygon) bool { return z.name == name }) {
+				delete(pos.zones, name)
+			}
+		}
+	}
+
+	for _, tr := range tracks {
+		center := image.Pt((tr.Box.Min.X+tr.Box.Max.X)/2, (tr.Box.Min.Y+tr.Box.Max.Y)/2)
+		pos, seen := c.tracks[tr.ID]
+		if !seen {
+			pos = &trackPosition{center: center, zones: make(map[string]bool)}
+			c.tracks[tr.ID] = pos
+		}
+
+		if seen {
+			for _, l := range lines {
+				before, after := orientation(l.a, l.b, pos.center), orientation(l.a, l.b, center)
+				if before*after >= 0 || !segmentsCross(pos.center, center, l.a, l.b) {
+					continue
+				}
+				if after > 0 {
+					event(proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_LINE_IN, l.name, tr)
+				} else {
+					event(proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_LINE_OUT, l.name, tr)
+				}
+			}
+		}
+
+		for _, z := range zones {
+			inside := z.contains(center)
+			switch {
+			case inside && !pos.zones[z.name]:
+				pos.zones[z.name] = true
+				event(proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_ENTER, z.name, tr)
+			case !inside && pos.zones[z.name]:
+				delete(pos.zones, z.name)
+				event(proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_LEAVE, z.name, tr)
+			}
+		}
+		pos.center = center
+	}
+
+	// a track ending in a zone has left it
+	for _, tr := range ended {
+		pos, ok := c.tracks[tr.ID]
+		if !ok {
+			continue
+		}
+		names := make([]string, 0, len(pos.zones))
+		for name := range pos.zones {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			event(proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_LEAVE, name, tr)
+		}
+		delete(c.tracks, tr.ID)
+	}
+	return events
+}
@@ -0,0 +1,188 @@
+package actors
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+const (
+	// analyticsResolution is the smallest bucket counts can be queried with
+	analyticsResolution = time.Minute
+	// AnalyticsRetention is how long counts are kept
+	AnalyticsRetention = 7 * 24 * time.Hour
+	// DefaultAnalyticsBucket is used when a query doesn't set a bucket size
+	DefaultAnalyticsBucket = time.Hour
+)
+
+type countKey struct {
+	name      string
+	eventType proto.AnalyticsEventType
+}
+
+// AnalyticsActor counts the AnalyticsEvents of each camera per minute and
+// answers GetAnalyticsCounts with those counts summed into larger buckets
+type AnalyticsActor struct {
+	counts    map[string]map[int64]map[countKey]int64 // camera → minute (unix millis) → counts
+	occupancy map[string]map[string]int64             // camera → zone → tracks inside
+	pruned    time.Time
+}
+
+var _ actor.Actor = (*AnalyticsActor)(nil)
+
+func NewAnalyticsActor() *AnalyticsActor {
+	return &AnalyticsActor{}
+}
+
+func (a *AnalyticsActor) PreStart(ctx *actor.Context) error {
+	a.counts = make(map[string]map[int64]map[countKey]int64)
+	a.occupancy = make(map[string]map[string]int64)
+	return nil
+}
+
+func (a *AnalyticsActor) Receive(ctx *actor.ReceiveContext) {
+	switch msg := ctx.Message().(type) {
+	case *proto.AnalyticsEvent:
+		a.record(msg)
+	case *proto.LifecycleEvent:
+		a.camera(msg)
+	case *proto.GetAnalyticsCounts:
+		ctx.Response(a.query(msg))
+	default:
+		ctx.Unhandled()
+	}
+}
+
+func (a *AnalyticsActor) PostStop(ctx *actor.Context) error {
+	return nil
+}
+
+func (a *AnalyticsActor) record(event *proto.AnalyticsEvent) {
+	minute := time.UnixMilli(event.Timestamp).Truncate(analyticsResolution).UnixMilli()
+	camera, ok := a.counts[event.CameraId]
+	if !ok {
+		camera = make(map[int64]map[countKey]int64)
+		a.counts[event.CameraId] = camera
+	}
+	counts, ok := camera[minute]
+	if !ok {
+		counts = make(map[countKey]int64)
+		camera[minute] = counts
+	}
+	counts[countKey{name: event.Name, eventType: event.Type}]++
+
+	switch event.Type {
+	case proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_ENTER:
+		if a.occupancy[event.CameraId] == nil {
+			a.occupancy[event.CameraId] = make(map[string]int64)
+		}
+		a.occupancy[event.CameraId][event.Name]++
+	case proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_LEAVE:
+		if a.occupancy[event.CameraId][event.Name] > 0 {
+			a.occupancy[event.CameraId][event.Name]--
+		}
+	}
+
+	a.prune(time.Now())
+}
+
+// camera drops the state of a removed camera, and of the zones and
+// tripwires an updated camera no longer has
+func (a *AnalyticsActor) camera(event *proto.LifecycleEvent) {
+	switch event.Type {
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED:
+		delete(a.counts, event.CameraId)
+		delete(a.occupancy, event.CameraId)
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPDATED:
+		for zone := range a.occupancy[event.CameraId] {
+			if !slices.Contains(event.Zones, zone) {
+				delete(a.occupancy[event.CameraId], zone)
+			}
+		}
+		for _, counts := range a.counts[event.CameraId] {
+			for key := range counts {
+				names := event.Zones
+				if key.eventType == proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_LINE_IN || key.eventType == proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_LINE_OUT {
+					names = event.Tripwires
+				}
+				if !slices.Contains(names, key.name) {
+					delete(counts, key)
+				}
+			}
+		}
+	}
+}
+
+// prune drops the counts older than AnalyticsRetention, at most once per minute
+func (a *AnalyticsActor) prune(now time.Time) {
+	if now.Sub(a.pruned) < analyticsResolution {
+		return
+	}
+	a.pruned = now
+	oldest := now.Add(-AnalyticsRetention).UnixMilli()
+	for cameraID, camera := range a.counts {
+		for minute := range camera {
+			if minute < oldest {
+				delete(camera, minute)
+			}
+		}
+		if len(camera) == 0 {
+			delete(a.counts, cameraID)
+		}
+	}
+}
+
+func (a *AnalyticsActor) query(q *proto.GetAnalyticsCounts) *proto.AnalyticsCounts {
+	bucket := time.Duration(q.BucketSeconds) * time.Second
+	if bucket <= 0 {
+		bucket = DefaultAnalyticsBucket
+	}
+	bucket = max(bucket.Truncate(analyticsResolution), analyticsResolution)
+	bucketMs := bucket.Milliseconds()
+
+	to := q.To
+	if to == 0 {
+		to = time.Now().UnixMilli()
+	}
+
+	buckets := make(map[int64]map[countKey]int64)
+	for minute, counts := range a.counts[q.CameraId] {
+		if minute < q.From || minute >= to {
+			continue
+		}
+		start := minute - minute%bucketMs
+		if buckets[start] == nil {
+			buckets[start] = make(map[countKey]int64)
+		}
+		for key, n := range counts {
+			buckets[start][key] += n
+		}
+	}
+
+	reply := &proto.AnalyticsCounts{
+		CameraId:      q.CameraId,
+		BucketSeconds: int64(bucket.Seconds()),
+		Occupancy:     make(map[string]int64),
+	}
+	for zone, n := range a.occupancy[q.CameraId] {
+		reply.Occupancy[zone] = n
+	}
+	for start, counts := range buckets {
+		b := &proto.AnalyticsBucket{Start: start}
+		for key, n := range counts {
+			b.Counts = append(b.Counts, &proto.AnalyticsCount{Name: key.name, Type: key.eventType, Count: n})
+		}
+		sort.Slice(b.Counts, func(i, j int) bool {
+			if b.Counts[i].Name != b.Counts[j].Name {
+				return b.Counts[i].Name < b.Counts[j].Name
+			}
+			return b.Counts[i].Type < b.Counts[j].Type
+		})
+		reply.Buckets = append(reply.Buckets, b)
+	}
+	sort.Slice(reply.Buckets, func(i, j int) bool { return reply.Buckets[i].Start < reply.Buckets[j].Start })
+	return reply
+}
@@ -0,0 +1,202 @@
+package actors
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/tracking"
+)
+
+// at is track 1 centered on (x, y)
+func at(x, y int) tracking.Track {
+	return tracking.Track{ID: 1, Label: "person", Box: image.Rect(x-10, y-10, x+10, y+10)}
+}
+
+// eventNames renders events as "type name", e.g. "ZONE_ENTER door"
+func eventNames(events []*proto.AnalyticsEvent) []string {
+	names := make([]string, 0, len(events))
+	for _, e := range events {
+		names = append(names, e.Type.String()[len("ANALYTICS_EVENT_TYPE_"):]+" "+e.Name)
+	}
+	return names
+}
+
+func TestCameraAnalyticsTransitions(t *testing.T) {
+	door := square("door", false, 100, 100, 100)
+	hall := square("hall", false, 300, 100, 100)
+	wire := line{name: "gate", a: image.Pt(250, 0), b: image.Pt(250, 500)}
+
+	// each step is a frame, the track is at pos unless it ended
+	type step struct {
+		pos   image.Point
+		ended bool
+		zones []polygon
+		want  []string
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "enter and leave a zone",
+			steps: []step{
+				{pos: image.Pt(50, 150), zones: []polygon{door}},
+				{pos: image.Pt(150, 150), zones: []polygon{door}, want: []string{"ZONE_ENTER door"}},
+				{pos: image.Pt(160, 150), zones: []polygon{door}},
+				{pos: image.Pt(50, 150), zones: []polygon{door}, want: []string{"ZONE_LEAVE door"}},
+			},
+		},
+		{
+			name: "first seen in a zone enters it",
+			steps: []step{
+				{pos: image.Pt(150, 150), zones: []polygon{door}, want: []string{"ZONE_ENTER door"}},
+			},
+		},
+		{
+			name: "ending in a zone leaves it",
+			steps: []step{
+				{pos: image.Pt(150, 150), zones: []polygon{door, hall}, want: []string{"ZONE_ENTER door"}},
+				{ended: true, zones: []polygon{door, hall}, want: []string{"ZONE_LEAVE door"}},
+			},
+		},
+		{
+			name: "moving across a tripwire between zones",
+			steps: []step{
+				{pos: image.Pt(150, 150), zones: []polygon{door, hall}, want: []string{"ZONE_ENTER door"}},
+				{pos: image.Pt(350, 150), zones: []polygon{door, hall}, want: []string{"LINE_OUT gate", "ZONE_LEAVE door", "ZONE_ENTER hall"}},
+				{pos: image.Pt(150, 150), zones: []polygon{door, hall}, want: []string{"LINE_IN gate", "ZONE_ENTER door", "ZONE_LEAVE hall"}},
+			},
+		},
+		{
+			name: "a removed zone is left silently",
+			steps: []step{
+				{pos: image.Pt(150, 150), zones: []polygon{door}, want: []string{"ZONE_ENTER door"}},
+				{pos: image.Pt(150, 150)},
+				{ended: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analytics := newCameraAnalytics()
+			for i, s := range tt.steps {
+				var tracks, ended []tracking.Track
+				if s.ended {
+					ended = []tracking.Track{at(0, 0)}
+				} else {
+					tracks = []tracking.Track{at(s.pos.X, s.pos.Y)}
+				}
+				got := eventNames(analytics.update(tracks, ended, []line{wire}, s.zones))
+				if !slices.Equal(got, s.want) {
+					t.Errorf("frame %d: got events %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsActorCameraChanges(t *testing.T) {
+	now := time.Now().UnixMilli()
+	record := func(a *AnalyticsActor, camera string, eventType proto.AnalyticsEventType, name string) {
+		a.record(&proto.AnalyticsEvent{CameraId: camera, Timestamp: now, Type: eventType, Name: name})
+	}
+	newActor := func() *AnalyticsActor {
+		a := NewAnalyticsActor()
+		_ = a.PreStart(nil)
+		record(a, "cam", proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_ENTER, "door")
+		record(a, "cam", proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_ENTER, "hall")
+		record(a, "cam", proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_LINE_IN, "gate")
+		record(a, "other", proto.AnalyticsEventType_ANALYTICS_EVENT_TYPE_ZONE_ENTER, "door")
+		return a
+	}
+	// names counted and occupied zones of cam
+	state := func(a *AnalyticsActor) (counted, occupied []string) {
+		counts := a.query(&proto.GetAnalyticsCounts{CameraId: "cam", To: now + 1})
+		for _, b := range counts.Buckets {
+			for _, c := range b.Counts {
+				counted = append(counted, c.Name)
+			}
+		}
+		for zone := range counts.Occupancy {
+			occupied = append(occupied, zone)
+		}
+		slices.Sort(occupied)
+		return counted, occupied
+	}
+
+	tests := []struct {
+		name         string
+		event        *proto.LifecycleEvent
+		wantCounted  []string
+		wantOccupied []string
+	}{
+		{
+			name:         "failure keeps everything",
+			event:        &proto.LifecycleEvent{CameraId: "cam", Type: proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_FAILED},
+			wantCounted:  []string{"door", "gate", "hall"},
+			wantOccupied: []string{"door", "hall"},
+		},
+		{
+			name:  "removed camera",
+			event: &proto.LifecycleEvent{CameraId: "cam", Type: proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED},
+		},
+		{
+			name:         "removed zone and tripwire",
+			event:        &proto.LifecycleEvent{CameraId: "cam", Type: proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPDATED, Zones: []string{"hall"}},
+			wantCounted:  []string{"hall"},
+			wantOccupied: []string{"hall"},
+		},
+		{
+			name:         "renamed zone",
+			event:        &proto.LifecycleEvent{CameraId: "cam", Type: proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPDATED, Zones: []string{"entrance", "hall"}, Tripwires: []string{"gate"}},
+			wantCounted:  []string{"gate", "hall"},
+			wantOccupied: []string{"hall"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newActor()
+			a.camera(tt.event)
+			counted, occupied := state(a)
+			if !slices.Equal(counted, tt.wantCounted) {
+				t.Errorf("counts of %v, want %v", counted, tt.wantCounted)
+			}
+			if !slices.Equal(occupied, tt.wantOccupied) {
+				t.Errorf("occupancy of %v, want %v", occupied, tt.wantOccupied)
+			}
+			if other := a.query(&proto.GetAnalyticsCounts{CameraId: "other", To: now + 1}); other.Occupancy["door"] != 1 {
+				t.Errorf("occupancy of the other camera changed: %v", other.Occupancy)
+			}
+		})
+	}
+}
+
+func TestValidateTripwires(t *testing.T) {
+	nan, inf := float32(math.NaN()), float32(math.Inf(1))
+	a, b := &proto.Point{X: 0.5, Y: 0}, &proto.Point{X: 0.5, Y: 1}
+	tests := []struct {
+		name      string
+		tripwires []*proto.Tripwire
+		wantErr   bool
+	}{
+		{name: "valid", tripwires: []*proto.Tripwire{{Name: "gate", A: a, B: b}}},
+		{name: "no name", tripwires: []*proto.Tripwire{{A: a, B: b}}, wantErr: true},
+		{name: "duplicate", tripwires: []*proto.Tripwire{{Name: "gate", A: a, B: b}, {Name: "gate", A: b, B: a}}, wantErr: true},
+		{name: "one point", tripwires: []*proto.Tripwire{{Name: "gate", A: a}}, wantErr: true},
+		{name: "same start and end", tripwires: []*proto.Tripwire{{Name: "gate", A: a, B: a}}, wantErr: true},
+		{name: "outside of the frame", tripwires: []*proto.Tripwire{{Name: "gate", A: &proto.Point{X: -0.1, Y: 0}, B: b}}, wantErr: true},
+		{name: "NaN coordinate", tripwires: []*proto.Tripwire{{Name: "gate", A: &proto.Point{X: nan, Y: 0}, B: b}}, wantErr: true},
+		{name: "infinite coordinate", tripwires: []*proto.Tripwire{{Name: "gate", A: a, B: &proto.Point{X: 0.5, Y: inf}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTripwires(tt.tripwires); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTripwires() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,114 +1,490 @@
 package actors
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"image"
 	"log"
+	"sync"
 	"time"
 
 	"gocv.io/x/gocv"
 
 	"github.com/tochemey/goakt/v3/actor"
+	"github.com/tochemey/goakt/v3/goaktpb"
+
+	"github.com/zaibon/surveilsense/live"
 	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/source"
 )
 
-const frameRate = time.Second // ~1 FPS
+// ReconnectPolicy controls how a CameraFeedActor recovers from a lost source
+type ReconnectPolicy struct {
+	InitialBackoff time.Duration // wait before the first retry
+	MaxBackoff     time.Duration // upper bound of the exponential backoff
+	Multiplier     float64       // backoff growth factor between attempts
+	MaxAttempts    int           // attempts before going offline, 0 retries forever
+	StallTimeout   time.Duration // time without frames before the source is reopened
+}
+
+// DefaultReconnectPolicy retries with a backoff from 1s to 1m and gives up after 10 attempts
+var DefaultReconnectPolicy = ReconnectPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+	Multiplier:     2,
+	MaxAttempts:    10,
+	StallTimeout:   10 * time.Second,
+}
+
+// creditTimeout is how long a camera waits for the processor to acknowledge
+// its frames before it assumes they were lost, e.g. by a restarted processor
+const creditTimeout = 30 * time.Second
+
+// backoff returns the delay to wait before the given attempt (starting at 1)
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	return backoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, attempt)
+}
+
+// backoff grows initial by multiplier at every attempt, up to max
+func backoff(initial, limit time.Duration, multiplier float64, attempt int) time.Duration {
+	d := initial
+	for i := 1; i < attempt; i++ {
+		d = time.Duration(float64(d) * multiplier)
+		if d >= limit {
+			return limit
+		}
+	}
+	return d
+}
 
 // CameraFeedActor captures frames and sends FrameData messages
 type CameraFeedActor struct {
-	cameraID  string
-	deviceID  int
-	capture   *gocv.VideoCapture
-	imgMat    gocv.Mat
-	quit      chan struct{}
-	processor *actor.PID
+	cameraID   string
+	source     source.FrameSource
+	policy     ReconnectPolicy
+	capture    *proto.CaptureConfig
+	processing *proto.ProcessingConfig
+	imgMat     gocv.Mat
+	quit       chan struct{}
+	done       chan struct{}
+	stopOnce   *sync.Once // goakt may call PostStop more than once
+	processor  *actor.PID
+	hub        *live.Hub
+
+	mu         sync.Mutex
+	status     *proto.CameraStatus
+	paused     bool
+	lastJPEG   []byte
+	lastJPEGAt int64
+
+	// flow control, frames are only sent while the processor acknowledges them
+	self         *actor.PID
+	inFlight     int
+	creditsSince time.Time // last time a frame was sent or acknowledged
+	pending      *proto.FrameData
+	sent         int64
+	dropped      int64
 }
 
 var _ actor.Actor = (*CameraFeedActor)(nil)
 
 // NewCameraFeedActor creates a CameraFeedActor with required dependencies
 func NewCameraFeedActor(processor *actor.PID) *CameraFeedActor {
+	return NewCameraFeedActorWithConfig("cam-1", source.NewVideoCaptureSource(source.Device(0)), nil, processor)
+}
+
+// NewCameraFeedActorWithConfig creates a CameraFeedActor for a specific camera ID, source and
+// capture settings. A nil config uses DefaultCaptureConfig.
+func NewCameraFeedActorWithConfig(cameraID string, src source.FrameSource, config *proto.CaptureConfig, processor *actor.PID) *CameraFeedActor {
+	if config == nil {
+		config = DefaultCaptureConfig()
+	}
 	return &CameraFeedActor{
-		cameraID:  "cam-1",
-		deviceID:  0,
-		quit:      make(chan struct{}),
-		processor: processor,
+		cameraID:   cameraID,
+		source:     src,
+		policy:     DefaultReconnectPolicy,
+		capture:    config,
+		processing: DefaultProcessingConfig(),
+		processor:  processor,
 	}
 }
 
-// NewCameraFeedActorWithConfig creates a CameraFeedActor for a specific camera ID and device ID
-func NewCameraFeedActorWithConfig(cameraID string, deviceID int, processor *actor.PID) *CameraFeedActor {
-	return &CameraFeedActor{
-		cameraID:  cameraID,
-		deviceID:  deviceID,
-		quit:      make(chan struct{}),
-		processor: processor,
+// WithReconnectPolicy overrides the DefaultReconnectPolicy
+func (a *CameraFeedActor) WithReconnectPolicy(policy ReconnectPolicy) *CameraFeedActor {
+	a.policy = policy
+	return a
+}
+
+// WithProcessingConfig sets the settings the FrameProcessorActor applies to this camera's frames
+func (a *CameraFeedActor) WithProcessingConfig(config *proto.ProcessingConfig) *CameraFeedActor {
+	if config != nil {
+		a.processing = config
 	}
+	return a
+}
+
+// WithLiveHub publishes every captured frame to the hub for live viewing
+func (a *CameraFeedActor) WithLiveHub(hub *live.Hub) *CameraFeedActor {
+	a.hub = hub
+	return a
 }
 
 func (a *CameraFeedActor) PreStart(ctx *actor.Context) error {
-	var err error
-	a.capture, err = gocv.OpenVideoCapture(a.deviceID)
-	if err != nil {
-		return err
-	}
-	if !a.capture.IsOpened() {
-		return err
-	}
+	a.status = &proto.CameraStatus{CameraId: a.cameraID}
+	a.setState(proto.CameraState_CAMERA_STATE_CONNECTING, nil)
+	a.self, a.inFlight, a.pending, a.sent, a.dropped = nil, 0, nil, 0, 0
 	a.imgMat = gocv.NewMat()
+	a.quit = make(chan struct{})
+	a.done = make(chan struct{})
+	a.stopOnce = new(sync.Once)
+	// the source is opened by the capture loop so an unreachable camera
+	// is retried in the background instead of failing the spawn
 	go a.captureLoop()
 
 	return nil
 }
 
 func (a *CameraFeedActor) captureLoop() {
+	defer close(a.done)
+	defer a.source.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			a.fail(fmt.Sprintf("capture panicked: %v", r))
+		}
+	}()
+
+	for {
+		if !a.connect() {
+			return
+		}
+		if !a.stream() {
+			return
+		}
+		// the stream stalled, close the source and reconnect
+		if err := a.source.Close(); err != nil {
+			log.Printf("CameraFeedActor: failed to close source for camera %s: %v", a.cameraID, err)
+		}
+	}
+}
+
+// connect opens the source, retrying with backoff.
+// It returns false when the actor is stopping or the camera went offline.
+func (a *CameraFeedActor) connect() bool {
+	for attempt := 1; ; attempt++ {
+		a.setState(proto.CameraState_CAMERA_STATE_CONNECTING, nil)
+		err := a.source.Open()
+		if err == nil {
+			a.mu.Lock()
+			a.status.ReconnectAttempts = 0
+			a.mu.Unlock()
+			a.setState(a.activeState(), nil)
+			return true
+		}
+
+		a.mu.Lock()
+		a.status.ReconnectAttempts = int32(attempt)
+		a.status.LastError = err.Error()
+		a.mu.Unlock()
+
+		if a.policy.MaxAttempts > 0 && attempt >= a.policy.MaxAttempts {
+			log.Printf("CameraFeedActor: camera %s offline after %d attempts: %v", a.cameraID, attempt, err)
+			a.setState(proto.CameraState_CAMERA_STATE_OFFLINE, err)
+			a.fail(fmt.Sprintf("offline after %d attempts: %v", attempt, err))
+			return false
+		}
+
+		wait := a.policy.backoff(attempt)
+		log.Printf("CameraFeedActor: failed to open camera %s (attempt %d), retrying in %s: %v", a.cameraID, attempt, wait, err)
+		if !a.sleep(wait) {
+			return false
+		}
+	}
+}
+
+// fail reports a capture failure to the actor, so its parent can restart it
+func (a *CameraFeedActor) fail(reason string) {
+	a.mu.Lock()
+	self := a.self
+	a.mu.Unlock()
+	if self == nil {
+		return
+	}
+	if err := self.Tell(context.Background(), self, &proto.CaptureFailed{Reason: reason}); err != nil {
+		log.Printf("CameraFeedActor: failed to report failure of camera %s: %v", a.cameraID, err)
+	}
+}
+
+// stream reads and forwards frames until the source stalls for longer than
+// the policy's StallTimeout. It returns false when the actor is stopping.
+func (a *CameraFeedActor) stream() bool {
+	lastFrame := time.Now()
 	for {
 		select {
 		case <-a.quit:
-			return
+			return false
 		default:
-			if ok := a.capture.Read(&a.imgMat); !ok || a.imgMat.Empty() {
-				time.Sleep(frameRate) // Wait before retrying
-				continue
-			}
-			// Resize to standard resolution
-			gocv.Resize(a.imgMat, &a.imgMat, image.Pt(640, 480), 0, 0, gocv.InterpolationDefault)
-			// Encode as JPEG
-			buf, err := gocv.IMEncode(gocv.JPEGFileExt, a.imgMat)
-			if err != nil {
-				log.Printf("failed to encode frame: %v", err)
-				continue
-			}
-			frame := &proto.FrameData{
-				CameraId:  a.cameraID,
-				Timestamp: time.Now().UnixMilli(),
-				ImageData: buf.GetBytes(),
+		}
+
+		if err := a.source.Read(&a.imgMat); err != nil {
+			a.setState(proto.CameraState_CAMERA_STATE_STALLED, err)
+			if time.Since(lastFrame) >= a.policy.StallTimeout {
+				log.Printf("CameraFeedActor: camera %s stalled for %s, reconnecting", a.cameraID, a.policy.StallTimeout)
+				return true
 			}
-			// Send to FrameProcessorActor
-			if a.processor != nil {
-				if err := actor.Tell(context.Background(), a.processor, frame); err != nil {
-					log.Printf("failed to send frame to processor: %v", err)
-				} else {
-					log.Printf("sent frame to processor: %s", a.cameraID)
-				}
+			if !a.sleep(a.frameInterval()) { // Wait before retrying
+				return false
 			}
-			buf.Close()
-			time.Sleep(frameRate)
+			continue
+		}
+		lastFrame = time.Now()
+		a.setState(a.activeState(), nil)
+		a.mu.Lock()
+		a.status.LastFrameAt = lastFrame.UnixMilli()
+		a.mu.Unlock()
+
+		a.sendFrame()
+		if !a.sleep(a.frameInterval()) {
+			return false
 		}
 	}
 }
 
+// sendFrame encodes the current frame, keeps it as the latest snapshot and
+// forwards it to the processor unless the camera is paused
+func (a *CameraFeedActor) sendFrame() {
+	a.mu.Lock()
+	capture := a.capture
+	processing := a.processing
+	paused := a.paused
+	a.mu.Unlock()
+
+	// Resize to the configured resolution
+	if size, ok := outputSize(image.Pt(a.imgMat.Cols(), a.imgMat.Rows()), capture); ok {
+		gocv.Resize(a.imgMat, &a.imgMat, size, 0, 0, gocv.InterpolationDefault)
+	}
+	// Encode as JPEG
+	buf, err := gocv.IMEncodeWithParams(gocv.JPEGFileExt, a.imgMat, []int{gocv.IMWriteJpegQuality, int(capture.JpegQuality)})
+	if err != nil {
+		log.Printf("failed to encode frame: %v", err)
+		return
+	}
+	// copy out of the native buffer, the frame outlives it
+	data := bytes.Clone(buf.GetBytes())
+	buf.Close()
+	captured := time.Now()
+	now := captured.UnixMilli()
+
+	a.mu.Lock()
+	a.lastJPEG = data
+	a.lastJPEGAt = now
+	a.mu.Unlock()
+
+	if a.hub != nil {
+		a.hub.Publish(a.cameraID, live.Raw, live.Frame{Data: data, Timestamp: captured})
+	}
+
+	if paused {
+		return
+	}
+	a.offer(&proto.FrameData{
+		CameraId:   a.cameraID,
+		Timestamp:  now,
+		ImageData:  data,
+		Processing: processing,
+	})
+}
+
+// offer sends the frame to the processor when it has a credit left.
+// Otherwise the drop policy picks the frame to drop.
+func (a *CameraFeedActor) offer(frame *proto.FrameData) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// frames are sent from the actor's PID so the processor can acknowledge them
+	if a.processor == nil || a.self == nil {
+		return
+	}
+
+	if a.inFlight >= credits(a.capture) && time.Since(a.creditsSince) > creditTimeout {
+		log.Printf("CameraFeedActor: no acknowledgement from processor for camera %s in %s, resetting %d credits", a.cameraID, creditTimeout, a.inFlight)
+		a.inFlight = 0
+	}
+	if a.inFlight < credits(a.capture) {
+		a.sendLocked(frame)
+		return
+	}
+
+	if a.capture.DropPolicy == proto.FrameDropPolicy_FRAME_DROP_POLICY_DROP_NEWEST {
+		a.dropped++
+		return
+	}
+	// keep the latest frame, replacing the one already waiting
+	if a.pending != nil {
+		a.dropped++
+	}
+	a.pending = frame
+}
+
+// acknowledge gives back the credit of a processed frame and sends the pending one
+func (a *CameraFeedActor) acknowledge() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.inFlight > 0 {
+		a.inFlight--
+	}
+	a.creditsSince = time.Now()
+	if a.pending != nil && a.inFlight < credits(a.capture) {
+		frame := a.pending
+		a.pending = nil
+		a.sendLocked(frame)
+	}
+}
+
+func (a *CameraFeedActor) sendLocked(frame *proto.FrameData) {
+	if err := a.self.Tell(context.Background(), a.processor, frame); err != nil {
+		log.Printf("CameraFeedActor: failed to send frame of camera %s to processor: %v", a.cameraID, err)
+		a.dropped++
+		return
+	}
+	a.inFlight++
+	a.sent++
+	a.creditsSince = time.Now()
+}
+
+func (a *CameraFeedActor) frameInterval() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return frameInterval(a.capture)
+}
+
+// sleep waits for d and returns false if the actor was stopped meanwhile
+func (a *CameraFeedActor) sleep(d time.Duration) bool {
+	select {
+	case <-a.quit:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// activeState is the state of a camera whose source delivers frames
+func (a *CameraFeedActor) activeState() proto.CameraState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.paused {
+		return proto.CameraState_CAMERA_STATE_PAUSED
+	}
+	return proto.CameraState_CAMERA_STATE_STREAMING
+}
+
+// setPaused toggles frame forwarding, the state only follows when frames are flowing
+func (a *CameraFeedActor) setPaused(paused bool) {
+	a.mu.Lock()
+	a.paused = paused
+	state := a.status.State
+	a.mu.Unlock()
+
+	if state == proto.CameraState_CAMERA_STATE_STREAMING || state == proto.CameraState_CAMERA_STATE_PAUSED {
+		a.setState(a.activeState(), nil)
+	}
+}
+
+func (a *CameraFeedActor) setState(state proto.CameraState, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err != nil {
+		a.status.LastError = err.Error()
+	}
+	if a.status.State == state {
+		return
+	}
+	a.status.State = state
+	a.status.StateSince = time.Now().UnixMilli()
+}
+
+// snapshotStatus returns a copy of the status safe to hand out of the actor
+func (a *CameraFeedActor) snapshotStatus() *proto.CameraStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &proto.CameraStatus{
+		CameraId:          a.status.CameraId,
+		State:             a.status.State,
+		StateSince:        a.status.StateSince,
+		ReconnectAttempts: a.status.ReconnectAttempts,
+		LastError:         a.status.LastError,
+		LastFrameAt:       a.status.LastFrameAt,
+		Capture:           a.capture,
+		Processing:        a.processing,
+		FramesSent:        a.sent,
+		FramesDropped:     a.dropped,
+		FramesInFlight:    int32(a.inFlight),
+	}
+}
+
 func (a *CameraFeedActor) Receive(ctx *actor.ReceiveContext) {
-	// No message handling for now
-	ctx.Unhandled()
+	switch msg := ctx.Message().(type) {
+	case *goaktpb.PostStart:
+		a.mu.Lock()
+		a.self = ctx.Self()
+		a.mu.Unlock()
+	case *proto.FrameProcessed:
+		a.acknowledge()
+	case *proto.CaptureFailed:
+		// handled by the supervisor of the actor
+		ctx.Err(errors.New(msg.Reason))
+	case *proto.GetCameraStatus:
+		ctx.Response(a.snapshotStatus())
+	case *proto.PauseCamera:
+		a.setPaused(true)
+		log.Printf("CameraFeedActor: paused camera %s", a.cameraID)
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.ResumeCamera:
+		a.setPaused(false)
+		log.Printf("CameraFeedActor: resumed camera %s", a.cameraID)
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.TakeSnapshot:
+		a.mu.Lock()
+		snapshot := &proto.Snapshot{
+			CameraId:  a.cameraID,
+			Timestamp: a.lastJPEGAt,
+			ImageData: a.lastJPEG,
+		}
+		a.mu.Unlock()
+		ctx.Response(snapshot)
+	case *proto.UpdateCaptureConfig:
+		if err := ValidateCaptureConfig(msg.Config); err != nil {
+			log.Printf("CameraFeedActor: rejected capture config for camera %s: %v", a.cameraID, err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		a.mu.Lock()
+		a.capture = msg.Config
+		a.mu.Unlock()
+		log.Printf("CameraFeedActor: updated capture config for camera %s", a.cameraID)
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.UpdateProcessingConfig:
+		if err := ValidateProcessingConfig(msg.Config); err != nil {
+			log.Printf("CameraFeedActor: rejected processing config for camera %s: %v", a.cameraID, err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		a.mu.Lock()
+		a.processing = msg.Config
+		a.mu.Unlock()
+		log.Printf("CameraFeedActor: updated processing config for camera %s", a.cameraID)
+		ctx.Response(&proto.CommandResult{Ok: true})
+	default:
+		ctx.Unhandled()
+	}
 }
 
 func (a *CameraFeedActor) PostStop(ctx *actor.Context) error {
-	close(a.quit)
-	if a.capture != nil {
-		a.capture.Close()
-	}
-	a.imgMat.Close()
+	a.stopOnce.Do(func() {
+		close(a.quit)
+		<-a.done
+		a.imgMat.Close()
+	})
 
 	return nil
 }
@@ -0,0 +1,97 @@
+package actors
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+const (
+	maxFPS      = 60
+	maxInFlight = 100
+)
+
+// DefaultCaptureConfig returns the capture settings used when none are given:
+// 1 FPS, 640x480 stretched, JPEG quality 95, at most 2 frames waiting for the
+// processor, keeping the latest one when it falls behind
+func DefaultCaptureConfig() *proto.CaptureConfig {
+	return &proto.CaptureConfig{
+		Fps:         1,
+		Width:       640,
+		Height:      480,
+		JpegQuality: 95,
+		MaxInFlight: 2,
+		DropPolicy:  proto.FrameDropPolicy_FRAME_DROP_POLICY_DROP_OLDEST,
+	}
+}
+
+// ValidateCaptureConfig checks that a CaptureConfig can be applied to a camera
+func ValidateCaptureConfig(c *proto.CaptureConfig) error {
+	if c == nil {
+		return fmt.Errorf("missing capture config")
+	}
+	// NaN fails every comparison, it would make the frame interval NaN
+	if math.IsNaN(c.Fps) || math.IsInf(c.Fps, 0) || c.Fps <= 0 || c.Fps > maxFPS {
+		return fmt.Errorf("fps must be in (0, %d], got %g", maxFPS, c.Fps)
+	}
+	if c.Width < 0 || c.Height < 0 {
+		return fmt.Errorf("resolution must not be negative, got %dx%d", c.Width, c.Height)
+	}
+	// a single dimension only makes sense when the other one follows the aspect ratio
+	if !c.KeepAspectRatio && (c.Width == 0) != (c.Height == 0) {
+		return fmt.Errorf("width and height must both be set unless the aspect ratio is kept, got %dx%d", c.Width, c.Height)
+	}
+	if c.JpegQuality < 1 || c.JpegQuality > 100 {
+		return fmt.Errorf("jpeg quality must be in [1, 100], got %d", c.JpegQuality)
+	}
+	if c.MaxInFlight < 1 || c.MaxInFlight > maxInFlight {
+		return fmt.Errorf("max in flight frames must be in [1, %d], got %d", maxInFlight, c.MaxInFlight)
+	}
+	if _, ok := proto.FrameDropPolicy_name[int32(c.DropPolicy)]; !ok {
+		return fmt.Errorf("unknown drop policy %d", c.DropPolicy)
+	}
+	return nil
+}
+
+// credits returns how many frames can be waiting for the processor
+func credits(c *proto.CaptureConfig) int {
+	return max(int(c.MaxInFlight), 1)
+}
+
+// frameInterval returns the delay between two captured frames
+func frameInterval(c *proto.CaptureConfig) time.Duration {
+	return time.Duration(float64(time.Second) / c.Fps)
+}
+
+// outputSize returns the size a frame of the given native size is resized to.
+// ok is false when the frame must be kept at its native size.
+func outputSize(native image.Point, c *proto.CaptureConfig) (size image.Point, ok bool) {
+	if c.Width == 0 && c.Height == 0 {
+		return native, false
+	}
+	if !c.KeepAspectRatio {
+		return image.Pt(int(c.Width), int(c.Height)), true
+	}
+
+	// scale to fit inside the box, a zero dimension is unconstrained
+	scale := 0.0
+	if c.Width > 0 {
+		scale = float64(c.Width) / float64(native.X)
+	}
+	if c.Height > 0 {
+		if s := float64(c.Height) / float64(native.Y); scale == 0 || s < scale {
+			scale = s
+		}
+	}
+	size = image.Pt(int(float64(native.X)*scale), int(float64(native.Y)*scale))
+	if size.X < 1 {
+		size.X = 1
+	}
+	if size.Y < 1 {
+		size.Y = 1
+	}
+	return size, size != native
+}
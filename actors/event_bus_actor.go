@@ -0,0 +1,219 @@
+package actors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	"github.com/tochemey/goakt/v3/actor"
+	"github.com/tochemey/goakt/v3/address"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// EventBusActor publishes the DetectionEvents, TrackEvents, AnalyticsEvents
+// and LifecycleEvents it receives to the actors subscribed to them. Subscriptions are added and
+// removed at runtime with Subscribe and Unsubscribe.
+type EventBusActor struct {
+	subscriptions map[string]*subscriber
+}
+
+type subscriber struct {
+	subscription *proto.Subscription
+	pid          *actor.PID       // local subscriber, resolved again by name when it stops running
+	addr         *address.Address // remote subscriber, resolved again by name when it can't be reached
+	missing      bool             // the subscriber couldn't be resolved, logged once until it is back
+}
+
+var _ actor.Actor = (*EventBusActor)(nil)
+
+func NewEventBusActor() *EventBusActor {
+	return &EventBusActor{}
+}
+
+func (a *EventBusActor) PreStart(ctx *actor.Context) error {
+	a.subscriptions = make(map[string]*subscriber)
+	return nil
+}
+
+func (a *EventBusActor) Receive(ctx *actor.ReceiveContext) {
+	switch msg := ctx.Message().(type) {
+	case *proto.DetectionEvent:
+		a.publish(ctx, proto.EventKind_EVENT_KIND_DETECTION, msg.CameraId, msg)
+	case *proto.TrackEvent:
+		a.publish(ctx, proto.EventKind_EVENT_KIND_TRACK, msg.CameraId, msg)
+	case *proto.AnalyticsEvent:
+		a.publish(ctx, proto.EventKind_EVENT_KIND_ANALYTICS, msg.CameraId, msg)
+	case *proto.LifecycleEvent:
+		a.publish(ctx, proto.EventKind_EVENT_KIND_LIFECYCLE, msg.CameraId, msg)
+	case *proto.Subscribe:
+		if err := a.subscribe(ctx, msg.Subscription); err != nil {
+			log.Printf("EventBusActor: rejected subscription: %v", err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.Unsubscribe:
+		if _, ok := a.subscriptions[msg.Id]; !ok {
+			ctx.Response(&proto.CommandResult{Error: fmt.Sprintf("unknown subscription %q", msg.Id)})
+			return
+		}
+		delete(a.subscriptions, msg.Id)
+		log.Printf("EventBusActor: removed subscription %s", msg.Id)
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.GetSubscriptions:
+		ctx.Response(a.list())
+	default:
+		ctx.Unhandled()
+	}
+}
+
+func (a *EventBusActor) PostStop(ctx *actor.Context) error {
+	a.subscriptions = nil
+	return nil
+}
+
+// ValidateSubscription checks the filter of a subscription
+func ValidateSubscription(s *proto.Subscription) error {
+	filter := s.GetFilter()
+	for _, kind := range filter.GetKinds() {
+		if _, ok := proto.EventKind_name[int32(kind)]; !ok || kind == proto.EventKind_EVENT_KIND_UNSPECIFIED {
+			return fmt.Errorf("unknown event kind %d", kind)
+		}
+	}
+	if c := filter.GetMinConfidence(); !finite(float64(c)) || c < 0 || c > 1 {
+		return fmt.Errorf("min confidence must be in [0, 1], got %g", c)
+	}
+	return nil
+}
+
+func (a *EventBusActor) subscribe(ctx *actor.ReceiveContext, s *proto.Subscription) error {
+	if s == nil {
+		return fmt.Errorf("subscription is empty")
+	}
+	if err := ValidateSubscription(s); err != nil {
+		return err
+	}
+	s = protobuf.Clone(s).(*proto.Subscription)
+	sub := &subscriber{subscription: s}
+	if s.Actor == "" {
+		// keep the sender rather than look it up by name, it may live on another node
+		switch sender, remote := ctx.Sender(), ctx.RemoteSender(); {
+		case sender != nil && !sender.Equals(actor.NoSender):
+			sub.pid = sender
+			s.Actor = sender.Name()
+		case remote != nil && !remote.Equals(address.NoSender()):
+			sub.addr = remote
+			s.Actor = remote.Name()
+		default:
+			return fmt.Errorf("subscription has no actor and was sent from outside of an actor")
+		}
+	}
+	if s.Id == "" {
+		s.Id = s.Actor
+	}
+	if sub.pid == nil && sub.addr == nil {
+		if err := sub.resolve(ctx.Context(), ctx.ActorSystem()); err != nil {
+			return fmt.Errorf("actor %q: %w", s.Actor, err)
+		}
+	}
+	a.subscriptions[s.Id] = sub
+	log.Printf("EventBusActor: %s subscribed as %s", s.Actor, s.Id)
+	return nil
+}
+
+func (a *EventBusActor) publish(ctx *actor.ReceiveContext, kind proto.EventKind, cameraID string, event protobuf.Message) {
+	for _, s := range a.subscriptions {
+		if !matches(s.subscription.GetFilter(), kind, cameraID, event) {
+			continue
+		}
+		err := s.resolve(ctx.Context(), ctx.ActorSystem())
+		if err == nil && s.pid != nil {
+			if err = actor.Tell(ctx.Context(), s.pid, event); err != nil {
+				// it may be stopping, look it up next time
+				s.pid = nil
+			}
+		} else if err == nil {
+			if err = ctx.Self().RemoteTell(ctx.Context(), s.addr, event); err != nil {
+				// the actor may have moved to another node, look it up next time
+				s.addr = nil
+			}
+		}
+		// events come at frame rate, only log the subscriber going missing
+		if err != nil {
+			if !s.missing {
+				log.Printf("EventBusActor: subscriber %s of %s is gone, dropping its events until it is back: %v", s.subscription.Actor, s.subscription.Id, err)
+				s.missing = true
+			}
+			continue
+		}
+		if s.missing {
+			log.Printf("EventBusActor: subscriber %s of %s is back", s.subscription.Actor, s.subscription.Id)
+			s.missing = false
+		}
+	}
+}
+
+// resolve looks the subscriber up by name, locally or across the cluster,
+// when its actor was stopped and spawned anew under the same name or can't be
+// reached anymore
+func (s *subscriber) resolve(ctx context.Context, system actor.ActorSystem) error {
+	if s.pid != nil && s.pid.IsRunning() || s.addr != nil {
+		return nil
+	}
+	addr, pid, err := system.ActorOf(ctx, s.subscription.Actor)
+	if err != nil {
+		return err
+	}
+	s.pid, s.addr = pid, nil
+	if pid == nil {
+		s.addr = addr
+	}
+	return nil
+}
+
+func matches(filter *proto.EventFilter, kind proto.EventKind, cameraID string, event protobuf.Message) bool {
+	kinds := filter.GetKinds()
+	if len(kinds) == 0 {
+		kinds = []proto.EventKind{proto.EventKind_EVENT_KIND_DETECTION}
+	}
+	if !slices.Contains(kinds, kind) {
+		return false
+	}
+	if cameras := filter.GetCameraIds(); len(cameras) > 0 && !slices.Contains(cameras, cameraID) {
+		return false
+	}
+
+	labels := filter.GetLabels()
+	switch event := event.(type) {
+	case *proto.DetectionEvent:
+		if len(labels) == 0 && filter.GetMinConfidence() == 0 {
+			return true
+		}
+		for _, d := range event.Detections {
+			if (len(labels) == 0 || slices.Contains(labels, d.Label)) && d.Confidence >= filter.GetMinConfidence() {
+				return true
+			}
+		}
+		return false
+	case *proto.TrackEvent:
+		return len(labels) == 0 || slices.Contains(labels, event.Label)
+	case *proto.AnalyticsEvent:
+		return len(labels) == 0 || slices.Contains(labels, event.Label)
+	case *proto.LifecycleEvent:
+		return len(labels) == 0
+	}
+	return true
+}
+
+func (a *EventBusActor) list() *proto.Subscriptions {
+	reply := &proto.Subscriptions{}
+	for _, s := range a.subscriptions {
+		reply.Subscriptions = append(reply.Subscriptions, s.subscription)
+	}
+	sort.Slice(reply.Subscriptions, func(i, j int) bool { return reply.Subscriptions[i].Id < reply.Subscriptions[j].Id })
+	return reply
+}
@@ -0,0 +1,97 @@
+package actors
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// syncBuffer is a log output safe to read while actors write to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestEventBusMissingSubscriber(t *testing.T) {
+	var logs syncBuffer
+	output := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(output) })
+
+	ctx := context.Background()
+	bus := spawn(t, NewEventBusActor())
+	system := bus.ActorSystem()
+	a := NewNotificationActor()
+	subscriber := &recorder{}
+	a.WithNotifier("recorder", subscriber, NotificationPolicy{})
+	pid, err := system.Spawn(ctx, "subscriber", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := actor.Ask(ctx, bus, &proto.Subscribe{Subscription: &proto.Subscription{Actor: "subscriber"}}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := resp.(*proto.CommandResult); !result.Ok {
+		t.Fatalf("Subscribe: %s", result.Error)
+	}
+
+	publish := func(n int) {
+		t.Helper()
+		for range n {
+			event := &proto.DetectionEvent{CameraId: "door", Timestamp: time.Now().UnixMilli(), Detections: []*proto.Detection{{Label: "person"}}}
+			if err := actor.Tell(ctx, bus, event); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the bus handles its messages in order, once it answered they are published
+		if _, err := actor.Ask(ctx, bus, &proto.GetSubscriptions{}, time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pid.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	publish(50)
+	if n := strings.Count(logs.String(), "subscriber subscriber of subscriber is gone"); n != 1 {
+		t.Errorf("logged the missing subscriber %d times, want once:\n%s", n, logs.String())
+	}
+
+	// spawned again under the same name, the subscriber gets the events again
+	a = NewNotificationActor()
+	a.WithNotifier("recorder", subscriber, NotificationPolicy{})
+	if _, err := system.Spawn(ctx, "subscriber", a); err != nil {
+		t.Fatal(err)
+	}
+	publish(1)
+	for deadline := time.Now().Add(time.Second); len(subscriber.recorded()) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := subscriber.recorded(); len(got) != 1 {
+		t.Errorf("subscriber told about %v once back, want the last event", got)
+	}
+	if n := strings.Count(logs.String(), "subscriber of subscriber is back"); n != 1 {
+		t.Errorf("logged the subscriber back %d times, want once", n)
+	}
+}
@@ -0,0 +1,48 @@
+package actors
+
+import (
+	"slices"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// DetectionFilter selects the detections of a DetectionEvent an actor acts upon.
+// The zero value lets everything through.
+type DetectionFilter struct {
+	Labels        []string // accepted labels, any when empty
+	MinConfidence float32  // detections below this confidence are dropped
+}
+
+func (f DetectionFilter) match(d *proto.Detection) bool {
+	if d.Confidence < f.MinConfidence {
+		return false
+	}
+	return len(f.Labels) == 0 || slices.Contains(f.Labels, d.Label)
+}
+
+// Apply returns the event restricted to the matching detections, or nil when
+// none match. The event is shared between actors so it is never modified.
+func (f DetectionFilter) Apply(event *proto.DetectionEvent) *proto.DetectionEvent {
+	if len(f.Labels) == 0 && f.MinConfidence <= 0 {
+		return event
+	}
+	detections := make([]*proto.Detection, 0, len(event.Detections))
+	for _, d := range event.Detections {
+		if f.match(d) {
+			detections = append(detections, d)
+		}
+	}
+	if len(detections) == 0 {
+		return nil
+	}
+	if len(detections) == len(event.Detections) {
+		return event
+	}
+	return &proto.DetectionEvent{
+		CameraId:   event.CameraId,
+		Timestamp:  event.Timestamp,
+		Detections: detections,
+		ImageClip:  event.ImageClip,
+		MotionOnly: event.MotionOnly,
+	}
+}
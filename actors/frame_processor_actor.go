@@ -1,44 +1,115 @@
 package actors
 
 import (
+	"bytes"
+	"fmt"
+	"image"
 	"image/color"
 	"log"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"gocv.io/x/gocv"
 
 	"github.com/tochemey/goakt/v3/actor"
+	protobuf "google.golang.org/protobuf/proto"
+
 	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/live"
 	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/tracking"
 )
 
-var blue = color.RGBA{0, 0, 255, 0}
+var (
+	blue   = color.RGBA{0, 0, 255, 0}
+	yellow = color.RGBA{255, 255, 0, 0}
+	green  = color.RGBA{0, 255, 0, 0}
+	red    = color.RGBA{255, 0, 0, 0}
+)
 
 // FrameProcessorActor receives FrameData and sends DetectionEvent
 type FrameProcessorActor struct {
-	detector detection.Detector
+	detectors *detection.Registry
+	hub       *live.Hub
+	bus       *actor.PID
+	motion    map[string]*motionGate // per camera, the background model is camera specific
+	trackers  map[string]*cameraTracker
+	analytics map[string]*cameraAnalytics
+	queued    *atomic.Int64 // frames routed to the actor by its pool and not received yet
+}
+
+// cameraTracker is the tracker of a camera along with the settings it was built with
+type cameraTracker struct {
+	tracker *tracking.Tracker
+	config  *proto.TrackingConfig
+}
+
+// motionGate is the motion detector of a camera along with the settings it was built with
+type motionGate struct {
+	detector    *detection.MotionDetector
+	sensitivity float32
+	minArea     int32
 }
 
 var _ actor.Actor = (*FrameProcessorActor)(nil)
 
-// NewFrameProcessorActor creates a FrameProcessorActor with the required PIDs
-func NewFrameProcessorActor(detector detection.Detector) *FrameProcessorActor {
+// NewFrameProcessorActor creates a FrameProcessorActor running the detectors
+// of the registry each camera selects in its ProcessingConfig
+func NewFrameProcessorActor(detectors *detection.Registry) *FrameProcessorActor {
 	return &FrameProcessorActor{
-		detector: detector,
+		detectors: detectors,
 	}
 }
 
+// WithLiveHub publishes every processed frame, with detection boxes drawn, to the hub
+func (a *FrameProcessorActor) WithLiveHub(hub *live.Hub) *FrameProcessorActor {
+	a.hub = hub
+	return a
+}
+
+// WithEventBus publishes the detection, track and analytics events to the EventBusActor
+func (a *FrameProcessorActor) WithEventBus(bus *actor.PID) *FrameProcessorActor {
+	a.bus = bus
+	return a
+}
+
 func (a *FrameProcessorActor) PreStart(ctx *actor.Context) error {
+	a.motion = make(map[string]*motionGate)
+	a.trackers = make(map[string]*cameraTracker)
+	a.analytics = make(map[string]*cameraAnalytics)
 	return nil
 }
 
 func (a *FrameProcessorActor) Receive(ctx *actor.ReceiveContext) {
-	msg := ctx.Message()
-	frame, ok := msg.(*proto.FrameData)
-	if !ok {
+	switch msg := ctx.Message().(type) {
+	case *proto.FrameData:
+		a.process(ctx, msg)
+	case *proto.LifecycleEvent:
+		// told by the pool about removed cameras
+		if msg.Type == proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED {
+			a.removeCamera(msg.CameraId)
+		}
+	default:
 		ctx.Unhandled()
-		return
 	}
+}
+
+// removeCamera drops the motion, tracking and analytics state of a camera
+func (a *FrameProcessorActor) removeCamera(cameraID string) {
+	if gate, ok := a.motion[cameraID]; ok {
+		gate.detector.Close()
+		delete(a.motion, cameraID)
+	}
+	delete(a.trackers, cameraID)
+	delete(a.analytics, cameraID)
+}
+
+func (a *FrameProcessorActor) process(ctx *actor.ReceiveContext, frame *proto.FrameData) {
+	if a.queued != nil {
+		a.queued.Add(-1)
+	}
+	defer acknowledge(ctx, frame)
 
 	// Decode JPEG image
 	imgMat, err := gocv.IMDecode(frame.ImageData, gocv.IMReadColor)
@@ -48,34 +119,82 @@ func (a *FrameProcessorActor) Receive(ctx *actor.ReceiveContext) {
 	}
 	defer imgMat.Close()
 
-	recs := a.detector.Detect(imgMat)
-	if len(recs) == 0 {
-		log.Printf("FrameProcessorActor: no objects detected in frame from camera %s", frame.CameraId)
-		return
-	}
+	size := image.Pt(imgMat.Cols(), imgMat.Rows())
+	zones := framePolygons(frame.GetProcessing().GetZones(), size)
 
-	log.Printf("FrameProcessorActor: detected %d objects in frame from camera %s", len(recs), frame.CameraId)
+	motionConfig := frame.GetProcessing().GetMotion()
+	var motionRegions []detection.Result
+	var motionZones [][]string
+	if motionConfig.GetEnabled() {
+		// motion in excluded areas, e.g. a TV, doesn't wake the detectors up
+		motionRegions, motionZones = applyZones(a.motionGate(frame.CameraId, motionConfig).detector.Detect(imgMat), zones)
+		if len(motionRegions) == 0 {
+			// static scene, skip the detectors. The tracks still age, so
+			// the objects that left end and leave their zones.
+			tracks, ended := a.track(ctx, frame, nil)
+			a.analyze(ctx, frame, tracks, ended, frameLines(frame.GetProcessing().GetTripwires(), size), zones)
+			a.publishOverlay(frame, frame.ImageData)
+			return
+		}
+	}
 
-	detections := make([]*proto.Detection, 0, len(recs))
-	for _, rec := range recs {
-		// draw a rectangle around each face on the original image
-		gocv.Rectangle(&imgMat, rec, blue, 3)
+	results, resultZones := applyZones(a.detect(frame, imgMat), zones)
+	tracks, ended := a.track(ctx, frame, results)
+	a.analyze(ctx, frame, tracks, ended, frameLines(frame.GetProcessing().GetTripwires(), size), zones)
+	motionOnly := false
+	boxColor := blue
+	if len(results) == 0 {
+		if !motionConfig.GetEmitEvents() || len(motionRegions) == 0 {
+			log.Printf("FrameProcessorActor: no objects detected in frame from camera %s", frame.CameraId)
+			// nothing to draw, the captured frame is the overlay
+			a.publishOverlay(frame, frame.ImageData)
+			return
+		}
+		// report the moving regions themselves
+		results, resultZones = motionRegions, motionZones
+		tracks = nil
+		motionOnly = true
+		boxColor = yellow
+		log.Printf("FrameProcessorActor: detected motion in %d regions in frame from camera %s", len(results), frame.CameraId)
+	} else {
+		log.Printf("FrameProcessorActor: detected %d objects in frame from camera %s", len(results), frame.CameraId)
+	}
 
-		detections = append(detections, &proto.Detection{
-			Confidence: 0.9, //TODO: set actual confidence if available
+	drawZones(&imgMat, zones)
+	detections := make([]*proto.Detection, 0, len(results))
+	for i, res := range results {
+		// draw a labelled rectangle around each detection on the original image
+		rec := res.Box
+		gocv.Rectangle(&imgMat, rec, boxColor, 3)
+		label := fmt.Sprintf("%s %.0f%%", res.Label, res.Confidence*100)
+		det := &proto.Detection{
+			Label:      res.Label,
+			Confidence: res.Confidence,
 			X:          int32(rec.Min.X),
 			Y:          int32(rec.Min.Y),
 			Width:      int32(rec.Dx()),
 			Height:     int32(rec.Dy()),
-		})
+			Zones:      resultZones[i],
+		}
+		if tracks != nil {
+			tr := tracks[i]
+			label = fmt.Sprintf("%s #%d %.0f%%", res.Label, tr.ID, res.Confidence*100)
+			det.TrackId = tr.ID
+			det.FirstSeen = tr.FirstSeen.UnixMilli()
+			det.DwellMs = tr.Dwell().Milliseconds()
+		}
+		gocv.PutText(&imgMat, label, image.Pt(rec.Min.X, max(rec.Min.Y-6, 12)), gocv.FontHersheySimplex, 0.5, boxColor, 1)
+		detections = append(detections, det)
 	}
 
 	// Optionally crop the detection region (for demo, send full frame)
 	buf, err := gocv.IMEncode(gocv.JPEGFileExt, imgMat)
 	var imageClip []byte
 	if err == nil {
-		imageClip = buf.GetBytes()
+		// copy out of the native buffer, the event outlives it
+		imageClip = bytes.Clone(buf.GetBytes())
 		buf.Close()
+		a.publishOverlay(frame, imageClip)
 	}
 
 	detectionEvent := &proto.DetectionEvent{
@@ -83,28 +202,190 @@ func (a *FrameProcessorActor) Receive(ctx *actor.ReceiveContext) {
 		Timestamp:  time.Now().UnixMilli(),
 		Detections: detections,
 		ImageClip:  imageClip,
+		MotionOnly: motionOnly,
 	}
 
-	// Send DetectionEvent to all NotificationActor and StorageActor instances
-	a.sendDetectionEvent(ctx, detectionEvent)
+	// The bus forwards the event to the NotificationActor, the StorageActor and any other subscriber
+	a.publish(ctx, detectionEvent)
 }
 
 func (a *FrameProcessorActor) PostStop(ctx *actor.Context) error {
+	for _, gate := range a.motion {
+		gate.detector.Close()
+	}
+	a.motion = nil
+	a.trackers = nil
+	a.analytics = nil
 	return nil
 }
 
-func (a *FrameProcessorActor) sendDetectionEvent(ctx *actor.ReceiveContext, event *proto.DetectionEvent) {
-	pids := ctx.ActorSystem().Actors()
-	for _, pid := range pids {
-		switch pid.Actor().(type) {
-		case *NotificationActor, *StorageActor:
-			if err := actor.Tell(ctx.Context(), pid, event); err != nil {
-				log.Printf("FrameProcessorActor: failed to send detection event to %s: %v", pid.Address(), err)
-			} else {
-				log.Printf("FrameProcessorActor: sent detection event to %s for camera %s", pid.Address(), event.CameraId)
+// acknowledge tells the camera the frame was handled, or dropped, so it can
+// send the next one
+func acknowledge(ctx *actor.ReceiveContext, frame *proto.FrameData) {
+	sender := ctx.Sender()
+	if sender == nil || sender.Equals(actor.NoSender) {
+		return
+	}
+	// the camera may be gone, which isn't an error of the receiver
+	if err := ctx.Self().Tell(ctx.Context(), sender, &proto.FrameProcessed{CameraId: frame.CameraId, Timestamp: frame.Timestamp}); err != nil {
+		log.Printf("%s: failed to acknowledge frame of camera %s: %v", ctx.Self().Name(), frame.CameraId, err)
+	}
+}
+
+// detect runs the detectors selected by the camera the frame comes from
+func (a *FrameProcessorActor) detect(frame *proto.FrameData, img gocv.Mat) []detection.Result {
+	names := []string{DefaultDetector}
+	if frame.Processing != nil {
+		names = frame.Processing.Detectors
+	}
+	var results []detection.Result
+	for _, name := range names {
+		detector, ok := a.detectors.Get(name)
+		if !ok {
+			if !a.detectors.Skipped(name) {
+				log.Printf("FrameProcessorActor: unknown detector %q for camera %s", name, frame.CameraId)
 			}
-		default:
 			continue
 		}
+		results = append(results, detector.Detect(img)...)
+	}
+	return results
+}
+
+// track feeds the results to the tracker of the camera and sends the track
+// events. It returns the track of each result, nil when tracking is off,
+// and the tracks that ended.
+func (a *FrameProcessorActor) track(ctx *actor.ReceiveContext, frame *proto.FrameData, results []detection.Result) (tracks, ended []tracking.Track) {
+	config := frame.GetProcessing().GetTracking()
+	current, ok := a.trackers[frame.CameraId]
+	if ok && !protobuf.Equal(current.config, config) {
+		// settings changed, the tracks of the old tracker end
+		ended = current.tracker.Flush()
+		a.sendTrackEvents(ctx, frame.CameraId, proto.TrackEventType_TRACK_EVENT_TYPE_ENDED, ended)
+		delete(a.trackers, frame.CameraId)
+		ok = false
+	}
+	if !config.GetEnabled() {
+		return nil, ended
+	}
+	if !ok {
+		current = &cameraTracker{
+			tracker: tracking.NewTracker(tracking.Config{
+				IoUThreshold: float64(config.IouThreshold),
+				MaxDistance:  float64(config.MaxDistance),
+				MaxMissed:    int(config.MaxMissed),
+				Kalman:       config.Kalman,
+			}),
+			config: config,
+		}
+		a.trackers[frame.CameraId] = current
+	}
+
+	update := current.tracker.Update(results, time.UnixMilli(frame.Timestamp))
+	a.sendTrackEvents(ctx, frame.CameraId, proto.TrackEventType_TRACK_EVENT_TYPE_ENDED, update.Ended)
+	a.sendTrackEvents(ctx, frame.CameraId, proto.TrackEventType_TRACK_EVENT_TYPE_STARTED, update.Started)
+	return update.Tracks, append(ended, update.Ended...)
+}
+
+// analyze publishes the tripwire crossings and zone changes of the tracks
+func (a *FrameProcessorActor) analyze(ctx *actor.ReceiveContext, frame *proto.FrameData, tracks, ended []tracking.Track, lines []line, zones []polygon) {
+	state, ok := a.analytics[frame.CameraId]
+	if !ok {
+		if len(tracks) == 0 {
+			return
+		}
+		state = newCameraAnalytics()
+		a.analytics[frame.CameraId] = state
+	}
+	events := state.update(tracks, ended, lines, zones)
+	if len(state.tracks) == 0 {
+		delete(a.analytics, frame.CameraId)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		event.CameraId = frame.CameraId
+		event.Timestamp = frame.Timestamp
+		log.Printf("FrameProcessorActor: track %d %s %s on camera %s", event.TrackId,
+			strings.ToLower(strings.TrimPrefix(event.Type.String(), "ANALYTICS_EVENT_TYPE_")), event.Name, frame.CameraId)
+		a.publish(ctx, event)
+	}
+}
+
+// motionGate returns the motion detector of the camera, rebuilding it when its settings changed
+func (a *FrameProcessorActor) motionGate(cameraID string, config *proto.MotionConfig) *motionGate {
+	gate, ok := a.motion[cameraID]
+	if ok && gate.sensitivity == config.Sensitivity && gate.minArea == config.MinArea {
+		return gate
+	}
+	if ok {
+		gate.detector.Close()
+	}
+	gate = &motionGate{
+		detector:    detection.NewMotionDetector(config.Sensitivity, int(config.MinArea)),
+		sensitivity: config.Sensitivity,
+		minArea:     config.MinArea,
+	}
+	a.motion[cameraID] = gate
+	return gate
+}
+
+// drawZones outlines the zones, include ones in green and exclude ones in red
+func drawZones(img *gocv.Mat, zones []polygon) {
+	for _, z := range zones {
+		zoneColor := green
+		if z.exclude {
+			zoneColor = red
+		}
+		pts := gocv.NewPointsVectorFromPoints([][]image.Point{z.points})
+		gocv.Polylines(img, pts, true, zoneColor, 1)
+		pts.Close()
+		gocv.PutText(img, z.name, z.points[0], gocv.FontHersheySimplex, 0.4, zoneColor, 1)
+	}
+}
+
+func (a *FrameProcessorActor) publishOverlay(frame *proto.FrameData, data []byte) {
+	if a.hub == nil || len(data) == 0 {
+		return
+	}
+	a.hub.Publish(frame.CameraId, live.Overlay, live.Frame{Data: data, Timestamp: time.UnixMilli(frame.Timestamp)})
+}
+
+func (a *FrameProcessorActor) sendTrackEvents(ctx *actor.ReceiveContext, cameraID string, eventType proto.TrackEventType, tracks []tracking.Track) {
+	for _, tr := range tracks {
+		log.Printf("FrameProcessorActor: track %d (%s) %s on camera %s after %s", tr.ID, tr.Label,
+			strings.ToLower(strings.TrimPrefix(eventType.String(), "TRACK_EVENT_TYPE_")), cameraID, tr.Dwell())
+		event := &proto.TrackEvent{
+			CameraId:  cameraID,
+			Timestamp: time.Now().UnixMilli(),
+			Type:      eventType,
+			TrackId:   tr.ID,
+			Label:     tr.Label,
+			FirstSeen: tr.FirstSeen.UnixMilli(),
+			LastSeen:  tr.LastSeen.UnixMilli(),
+			Detection: &proto.Detection{
+				Label:     tr.Label,
+				X:         int32(tr.Box.Min.X),
+				Y:         int32(tr.Box.Min.Y),
+				Width:     int32(tr.Box.Dx()),
+				Height:    int32(tr.Box.Dy()),
+				TrackId:   tr.ID,
+				FirstSeen: tr.FirstSeen.UnixMilli(),
+				DwellMs:   tr.Dwell().Milliseconds(),
+			},
+		}
+		a.publish(ctx, event)
+	}
+}
+
+// publish sends an event to the bus, which forwards it to its subscribers
+func (a *FrameProcessorActor) publish(ctx *actor.ReceiveContext, event protobuf.Message) {
+	if a.bus == nil {
+		return
+	}
+	if err := actor.Tell(ctx.Context(), a.bus, event); err != nil {
+		log.Printf("FrameProcessorActor: failed to publish %T: %v", event, err)
 	}
 }
@@ -0,0 +1,280 @@
+package actors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+	"github.com/tochemey/goakt/v3/goaktpb"
+
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// RoutingStrategy picks the FrameProcessorActor of a pool handling a frame
+type RoutingStrategy string
+
+const (
+	// RoundRobinRouting spreads the frames evenly. The motion and tracking
+	// state of a camera is then split across processors, only use it when
+	// both are off.
+	RoundRobinRouting RoutingStrategy = "round-robin"
+	// CameraHashRouting always sends the frames of a camera to the same processor
+	CameraHashRouting RoutingStrategy = "camera-hash"
+)
+
+// ParseRoutingStrategy parses round-robin or camera-hash
+func ParseRoutingStrategy(s string) (RoutingStrategy, error) {
+	switch strategy := RoutingStrategy(s); strategy {
+	case RoundRobinRouting, CameraHashRouting:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("unknown routing strategy %q, expected %s or %s", s, RoundRobinRouting, CameraHashRouting)
+}
+
+// FrameProcessorPool routes the FrameData it receives to a pool of
+// FrameProcessorActors spawned as its children. goakt's own router builds
+// its routees from zero values and can't route by key, hence this actor.
+// It answers GetPoolStatus with the queue depth of every processor, and
+// subscribed to the LifecycleEvents, drops the state of removed cameras.
+// A failing processor is restarted following the pool's RestartPolicy.
+type FrameProcessorPool struct {
+	size         int
+	strategy     RoutingStrategy
+	detectors    *detection.Registry
+	newProcessor func(detectors *detection.Registry) *FrameProcessorActor
+	policy       RestartPolicy
+	bus          *actor.PID
+
+	workers []*poolWorker
+	next    uint64
+}
+
+type poolWorker struct {
+	name      string
+	pid       *actor.PID
+	processor *FrameProcessorActor // spawned again on restart, keeping its detectors
+	detectors *detection.Registry  // fork owned by the worker
+	queued    *atomic.Int64
+	cameras   map[string]bool
+	failures  childFailures
+}
+
+var _ actor.Actor = (*FrameProcessorPool)(nil)
+
+// NewFrameProcessorPool creates a pool of size processors. Each processor is
+// built by newProcessor with its own fork of detectors.
+func NewFrameProcessorPool(size int, strategy RoutingStrategy, detectors *detection.Registry, newProcessor func(detectors *detection.Registry) *FrameProcessorActor) *FrameProcessorPool {
+	return &FrameProcessorPool{
+		size:         max(size, 1),
+		strategy:     strategy,
+		detectors:    detectors,
+		newProcessor: newProcessor,
+		policy:       DefaultProcessorRestartPolicy,
+	}
+}
+
+// WithRestartPolicy overrides the DefaultProcessorRestartPolicy
+func (a *FrameProcessorPool) WithRestartPolicy(policy RestartPolicy) *FrameProcessorPool {
+	a.policy = policy
+	return a
+}
+
+// WithEventBus publishes the failures and restarts of the processors as LifecycleEvents
+func (a *FrameProcessorPool) WithEventBus(bus *actor.PID) *FrameProcessorPool {
+	a.bus = bus
+	return a
+}
+
+func (a *FrameProcessorPool) PreStart(ctx *actor.Context) error {
+	a.workers = nil
+	return nil
+}
+
+func (a *FrameProcessorPool) Receive(ctx *actor.ReceiveContext) {
+	switch msg := ctx.Message().(type) {
+	case *goaktpb.PostStart:
+		a.spawnWorkers(ctx)
+	case *proto.FrameData:
+		a.route(ctx, msg)
+	case *proto.GetPoolStatus:
+		ctx.Response(a.status())
+	case *proto.LifecycleEvent:
+		if msg.Type == proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED && msg.CameraId != "" {
+			a.removeCamera(ctx, msg)
+		}
+	case *goaktpb.Mayday:
+		a.fail(ctx, ctx.Sender(), msg.Reason)
+	case *proto.RestartChild:
+		a.restart(ctx, msg.Name)
+	default:
+		ctx.Unhandled()
+	}
+}
+
+func (a *FrameProcessorPool) PostStop(ctx *actor.Context) error {
+	// the workers are stopped before their parent, their detectors can go
+	for _, w := range a.workers {
+		w.detectors.Close()
+	}
+	a.workers = nil
+	return nil
+}
+
+func (a *FrameProcessorPool) spawnWorkers(ctx *actor.ReceiveContext) {
+	for i := range a.size {
+		detectors, err := a.detectors.Fork()
+		if err != nil {
+			ctx.Err(err)
+			return
+		}
+		queued := new(atomic.Int64)
+		processor := a.newProcessor(detectors)
+		processor.queued = queued
+		name := fmt.Sprintf("%s-%d", ctx.Self().Name(), i)
+		pid := ctx.Spawn(name, processor, escalate())
+		if pid == nil {
+			detectors.Close()
+			return
+		}
+		a.workers = append(a.workers, &poolWorker{name: name, pid: pid, processor: processor, detectors: detectors, queued: queued, cameras: make(map[string]bool)})
+	}
+	log.Printf("FrameProcessorPool: started %d processors with %s routing", len(a.workers), a.strategy)
+}
+
+func (a *FrameProcessorPool) route(ctx *actor.ReceiveContext, frame *proto.FrameData) {
+	var w *poolWorker
+	switch a.strategy {
+	case CameraHashRouting:
+		if len(a.workers) == 0 {
+			break
+		}
+		// hash over every processor, running or not: a camera moved to
+		// another processor would start over its tracks and zone state there
+		h := fnv.New32a()
+		h.Write([]byte(frame.CameraId))
+		w = a.workers[h.Sum32()%uint32(len(a.workers))]
+		if !w.pid.IsRunning() {
+			// the processor is restarting, its failure is already reported
+			acknowledge(ctx, frame)
+			return
+		}
+		w.cameras[frame.CameraId] = true
+	default:
+		running := make([]*poolWorker, 0, len(a.workers))
+		for _, w := range a.workers {
+			if w.pid.IsRunning() {
+				running = append(running, w)
+			}
+		}
+		if len(running) > 0 {
+			w = running[a.next%uint64(len(running))]
+			a.next++
+		}
+	}
+	if w == nil {
+		log.Printf("FrameProcessorPool: no processor running, dropping frame from camera %s", frame.CameraId)
+		// give the camera its credit back rather than wait for the credit timeout
+		acknowledge(ctx, frame)
+		return
+	}
+	w.queued.Add(1)
+	// keep the camera as the sender, the processor acknowledges the frame to it
+	ctx.Forward(w.pid)
+}
+
+// removeCamera forgets a removed camera and tells the processors to drop its state
+func (a *FrameProcessorPool) removeCamera(ctx *actor.ReceiveContext, event *proto.LifecycleEvent) {
+	for _, w := range a.workers {
+		delete(w.cameras, event.CameraId)
+		if w.pid.IsRunning() {
+			ctx.Tell(w.pid, event)
+		}
+	}
+}
+
+// fail stops the failing processor, it is restarted once the backoff is over
+func (a *FrameProcessorPool) fail(ctx *actor.ReceiveContext, pid *actor.PID, reason string) {
+	for _, w := range a.workers {
+		if pid == nil || !w.pid.Equals(pid) {
+			continue
+		}
+		// the failed processor is suspended, stop it until its restart
+		if err := w.pid.Shutdown(ctx.Context()); err != nil {
+			log.Printf("FrameProcessorPool: failed to stop %s: %v", w.name, err)
+		}
+		a.failed(ctx, w, reason)
+	}
+}
+
+// failed records the failure of a processor and schedules its restart,
+// unless it failed too often
+func (a *FrameProcessorPool) failed(ctx *actor.ReceiveContext, w *poolWorker, reason string) {
+	wait, restart := w.failures.fail(a.policy, reason, time.Now())
+	event := &proto.LifecycleEvent{
+		Actor:    w.name,
+		Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_FAILED,
+		Reason:   reason,
+		Restarts: w.failures.restarts,
+	}
+	if !restart {
+		event.Type = proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_STOPPED
+	}
+	publishLifecycle(ctx.Context(), a.bus, event)
+	if restart {
+		scheduleRestart(ctx.Self(), w.name, wait)
+	}
+}
+
+// restart spawns the processor again with its detectors
+func (a *FrameProcessorPool) restart(ctx *actor.ReceiveContext, name string) {
+	for _, w := range a.workers {
+		if w.name != name || w.failures.stopped {
+			continue
+		}
+		// the frames of the failed mailbox are lost
+		w.queued.Store(0)
+		pid, err := ctx.Self().SpawnChild(ctx.Context(), w.name, w.processor, escalate())
+		if err != nil {
+			a.failed(ctx, w, fmt.Sprintf("restart: %v", err))
+			return
+		}
+		w.pid = pid
+		w.failures.restarts++
+		publishLifecycle(ctx.Context(), a.bus, &proto.LifecycleEvent{
+			Actor:    w.name,
+			Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_RESTARTED,
+			Reason:   w.failures.lastFailure,
+			Restarts: w.failures.restarts,
+		})
+	}
+}
+
+func (a *FrameProcessorPool) status() *proto.PoolStatus {
+	status := &proto.PoolStatus{Strategy: string(a.strategy)}
+	for _, w := range a.workers {
+		cameras := make([]string, 0, len(w.cameras))
+		for cameraID := range w.cameras {
+			cameras = append(cameras, cameraID)
+		}
+		sort.Strings(cameras)
+		processor := &proto.ProcessorStatus{
+			Name:        w.name,
+			QueueDepth:  w.queued.Load(),
+			Processed:   int64(w.pid.ProcessedCount()),
+			Restarts:    w.failures.restarts,
+			Cameras:     cameras,
+			LastFailure: w.failures.lastFailure,
+			Running:     w.pid.IsRunning(),
+		}
+		if !w.failures.lastAt.IsZero() {
+			processor.LastFailureAt = w.failures.lastAt.UnixMilli()
+		}
+		status.Processors = append(status.Processors, processor)
+	}
+	return status
+}
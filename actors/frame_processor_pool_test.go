@@ -0,0 +1,122 @@
+package actors
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// acker is a camera counting the frames acknowledged to it, and
+// remembering the processor of each camera
+type acker struct {
+	acked      atomic.Int64
+	mu         sync.Mutex
+	processors map[string]*actor.PID
+}
+
+func (a *acker) PreStart(ctx *actor.Context) error { return nil }
+func (a *acker) PostStop(ctx *actor.Context) error { return nil }
+func (a *acker) Receive(ctx *actor.ReceiveContext) {
+	if msg, ok := ctx.Message().(*proto.FrameProcessed); ok {
+		a.mu.Lock()
+		a.processors[msg.CameraId] = ctx.Sender()
+		a.mu.Unlock()
+		a.acked.Add(1)
+	}
+}
+
+func (a *acker) processor(cameraID string) *actor.PID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.processors[cameraID]
+}
+
+// cameraOf returns a camera ID routed to the processor i of a pool of size
+func cameraOf(i, size int) string {
+	for n := 0; ; n++ {
+		id := fmt.Sprintf("camera-%d", n)
+		h := fnv.New32a()
+		h.Write([]byte(id))
+		if int(h.Sum32()%uint32(size)) == i {
+			return id
+		}
+	}
+}
+
+func TestFrameProcessorPoolCameraHash(t *testing.T) {
+	ctx := context.Background()
+	pool := spawn(t, NewFrameProcessorPool(2, CameraHashRouting, detection.NewRegistry(), func(detectors *detection.Registry) *FrameProcessorActor {
+		return NewFrameProcessorActor(detectors)
+	}))
+	camera := &acker{processors: make(map[string]*actor.PID)}
+	cameraPID, err := pool.ActorSystem().Spawn(ctx, "camera", camera)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// cameras of each processor, as reported by the pool
+	cameras := func() [][]string {
+		t.Helper()
+		resp, err := actor.Ask(ctx, pool, &proto.GetPoolStatus{}, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cameras [][]string
+		for _, p := range resp.(*proto.PoolStatus).Processors {
+			cameras = append(cameras, p.Cameras)
+		}
+		return cameras
+	}
+	send := func(cameraID string) {
+		t.Helper()
+		// undecodable, the processor acknowledges it right away
+		if err := cameraPID.Tell(ctx, pool, &proto.FrameData{CameraId: cameraID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitAcked := func(want int64) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); camera.acked.Load() < want && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if got := camera.acked.Load(); got != want {
+			t.Fatalf("%d frames acknowledged, want %d", got, want)
+		}
+	}
+
+	a, b := cameraOf(0, 2), cameraOf(1, 2)
+	send(a)
+	send(b)
+	waitAcked(2)
+	if got, want := cameras(), [][]string{{a}, {b}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("processors handle %v, want %v", got, want)
+	}
+
+	// the frames of a stopped processor are dropped, not moved to another one
+	if err := camera.processor(b).Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	send(b)
+	waitAcked(3)
+	if got, want := cameras(), [][]string{{a}, {b}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("processors handle %v after processor 1 stopped, want %v", got, want)
+	}
+
+	// a removed camera is forgotten
+	if err := actor.Tell(ctx, pool, &proto.LifecycleEvent{Type: proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED, CameraId: a}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cameras(), [][]string{{}, {b}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("processors handle %v after %s was removed, want %v", got, want, a)
+	}
+}
@@ -1,46 +1,369 @@
 package actors
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/tochemey/goakt/v3/actor"
+	protobuf "google.golang.org/protobuf/proto"
+
 	"github.com/zaibon/surveilsense/proto"
 )
 
+// Notifier tells a service about an event. Notify runs outside of the
+// NotificationActor and gives up when ctx is done.
 type Notifier interface {
-	Notify(event *proto.DetectionEvent) error
+	Notify(ctx context.Context, event *proto.DetectionEvent) error
+}
+
+// deliveryTimeout bounds a notification, retries included
+const deliveryTimeout = 2 * time.Minute
+
+// NotificationPolicy limits how often a notifier is told about a camera
+type NotificationPolicy struct {
+	Cooldown  time.Duration // minimum time between two notifications for a camera, 0 notifies every event
+	Aggregate bool          // notify the events held back by the cooldown as one when it ends, instead of dropping them
 }
 
-// NotificationActor receives DetectionEvent and notifies via all configured Notifiers
+// DefaultNotificationPolicy notifies a camera at most once a minute, with
+// the events of the minute summed up
+var DefaultNotificationPolicy = NotificationPolicy{Cooldown: time.Minute, Aggregate: true}
+
+// NotificationActor receives DetectionEvent and notifies via all configured Notifiers.
+// With AlertRules, a notifier is only told about the detections of the rules
+// naming it. The rules are managed at runtime and saved in the RuleStore.
+// Each notifier has a cooldown per camera following its NotificationPolicy.
+// The notifications are delivered in the background, a slow or rate limited
+// service doesn't hold back the actor.
 type NotificationActor struct {
-	notifiers []Notifier
+	notifiers []*notifierEntry
+	filter    DetectionFilter
+	store     RuleStore
+
+	rules     []*proto.AlertRule // sorted by id
+	cooldowns map[cooldownKey]*cooldown
+
+	deliveries context.Context // canceled when the actor stops
+	cancel     context.CancelFunc
+
+	// the cooldown timers, stopped with the actor. goakt may still be in
+	// Receive when PostStop runs, which only touches what the mutex guards.
+	mu     sync.Mutex
+	timers map[*time.Timer]bool
+}
+
+type notifierEntry struct {
+	name     string
+	notifier Notifier
+	policy   NotificationPolicy
+}
+
+type cooldownKey struct {
+	cameraID string
+	notifier int
+}
+
+// cooldown is the window after a notification during which the events of
+// the camera are held back
+type cooldown struct {
+	until   time.Time
+	pending *proto.DetectionEvent // latest event held back, carrying the aggregate
 }
 
-// NewNotificationActor creates a NotificationActor with the given notifiers
+// NewNotificationActor creates a NotificationActor with the given notifiers,
+// following the DefaultNotificationPolicy
 func NewNotificationActor(notifiers ...Notifier) *NotificationActor {
-	return &NotificationActor{notifiers: notifiers}
+	a := &NotificationActor{}
+	for _, n := range notifiers {
+		a.WithNotifier("", n, DefaultNotificationPolicy)
+	}
+	return a
+}
+
+// WithNotifier adds a notifier following its own policy. The name shows in
+// the logs, the type of the notifier when empty.
+func (a *NotificationActor) WithNotifier(name string, notifier Notifier, policy NotificationPolicy) *NotificationActor {
+	if name == "" {
+		name = fmt.Sprintf("%T", notifier)
+	}
+	a.notifiers = append(a.notifiers, &notifierEntry{name: name, notifier: notifier, policy: policy})
+	return a
+}
+
+// WithRuleStore loads the alert rules from store, and saves them there when they change
+func (a *NotificationActor) WithRuleStore(store RuleStore) *NotificationActor {
+	a.store = store
+	return a
+}
+
+// WithFilter only notifies about the detections matching filter
+func (a *NotificationActor) WithFilter(filter DetectionFilter) *NotificationActor {
+	a.filter = filter
+	return a
 }
 
 var _ actor.Actor = (*NotificationActor)(nil)
 
 func (a *NotificationActor) PreStart(ctx *actor.Context) error {
+	a.cooldowns = make(map[cooldownKey]*cooldown)
+	a.deliveries, a.cancel = context.WithCancel(context.Background())
+	a.mu.Lock()
+	a.timers = make(map[*time.Timer]bool)
+	a.mu.Unlock()
+	if a.store != nil {
+		rules, err := a.store.LoadRules()
+		if err != nil {
+			return fmt.Errorf("load alert rules: %w", err)
+		}
+		if err := a.setRules(rules); err != nil {
+			return fmt.Errorf("load alert rules: %w", err)
+		}
+	}
 	return nil
 }
 
 func (a *NotificationActor) Receive(ctx *actor.ReceiveContext) {
-	msg := ctx.Message()
-	event, ok := msg.(*proto.DetectionEvent)
-	if !ok {
+	switch msg := ctx.Message().(type) {
+	case *proto.DetectionEvent:
+		event := a.filter.Apply(msg)
+		if event == nil {
+			return
+		}
+		now := time.Now()
+		for i, event := range a.route(event) {
+			a.offer(ctx, cooldownKey{cameraID: event.CameraId, notifier: i}, event, now)
+		}
+	case *proto.PutAlertRule:
+		rules := slices.DeleteFunc(slices.Clone(a.rules), func(r *proto.AlertRule) bool { return r.Id == msg.GetRule().GetId() })
+		a.updateRules(ctx, append(rules, msg.GetRule()))
+	case *proto.DeleteAlertRule:
+		if !slices.ContainsFunc(a.rules, func(r *proto.AlertRule) bool { return r.Id == msg.Id }) {
+			ctx.Response(&proto.CommandResult{Error: fmt.Sprintf("unknown rule %q", msg.Id)})
+			return
+		}
+		a.updateRules(ctx, slices.DeleteFunc(slices.Clone(a.rules), func(r *proto.AlertRule) bool { return r.Id == msg.Id }))
+	case *proto.SetAlertRules:
+		a.updateRules(ctx, msg.Rules)
+	case *proto.ReloadAlertRules:
+		if err := a.reloadRules(); err != nil {
+			log.Printf("NotificationActor: failed to reload alert rules: %v", err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		log.Printf("NotificationActor: reloaded %d alert rules", len(a.rules))
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.GetAlertRules:
+		ctx.Response(&proto.AlertRules{Rules: a.rules})
+	case *proto.NotificationCooldownEnded:
+		a.endCooldown(ctx, cooldownKey{cameraID: msg.CameraId, notifier: int(msg.Notifier)}, time.Now())
+	default:
 		ctx.Unhandled()
+	}
+}
+
+func (a *NotificationActor) PostStop(ctx *actor.Context) error {
+	// the deliveries in progress give up and the events held back are lost
+	a.cancel()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for timer := range a.timers {
+		timer.Stop()
+	}
+	clear(a.timers)
+	return nil
+}
+
+// route returns the event each notifier is told about, by notifier index.
+// Without rules, every notifier is told about the whole event. Otherwise a
+// notifier gets the detections of the rules naming it, if any.
+func (a *NotificationActor) route(event *proto.DetectionEvent) map[int]*proto.DetectionEvent {
+	routed := make(map[int]*proto.DetectionEvent)
+	if len(a.rules) == 0 {
+		for i := range a.notifiers {
+			routed[i] = event
+		}
+		return routed
+	}
+
+	matched := make(map[int]map[*proto.Detection]bool)
+	t := time.UnixMilli(event.Timestamp)
+	for _, rule := range a.rules {
+		detections := matchRule(rule, event, t)
+		if len(detections) == 0 {
+			continue
+		}
+		for i, n := range a.notifiers {
+			if !slices.Contains(rule.Notifiers, n.name) {
+				continue
+			}
+			if matched[i] == nil {
+				matched[i] = make(map[*proto.Detection]bool)
+			}
+			for _, d := range detections {
+				matched[i][d] = true
+			}
+		}
+	}
+	for i, detections := range matched {
+		if len(detections) == len(event.Detections) {
+			routed[i] = event
+			continue
+		}
+		// keep the detections in the order of the event
+		routed[i] = &proto.DetectionEvent{
+			CameraId:   event.CameraId,
+			Timestamp:  event.Timestamp,
+			Detections: slices.DeleteFunc(slices.Clone(event.Detections), func(d *proto.Detection) bool { return !detections[d] }),
+			ImageClip:  event.ImageClip,
+			MotionOnly: event.MotionOnly,
+		}
+	}
+	return routed
+}
+
+// updateRules replaces the rules, saves them and replies with the result
+func (a *NotificationActor) updateRules(ctx *actor.ReceiveContext, rules []*proto.AlertRule) {
+	if err := a.setRules(rules); err != nil {
+		log.Printf("NotificationActor: rejected alert rules: %v", err)
+		ctx.Response(&proto.CommandResult{Error: err.Error()})
 		return
 	}
-	for _, notifier := range a.notifiers {
-		if err := notifier.Notify(event); err != nil {
-			log.Printf("NotificationActor: failed to notify: %v", err)
+	if a.store != nil {
+		if err := a.store.SaveRules(a.rules); err != nil {
+			log.Printf("NotificationActor: failed to save alert rules: %v", err)
 		}
 	}
+	ctx.Response(&proto.CommandResult{Ok: true})
 }
 
-func (a *NotificationActor) PostStop(ctx *actor.Context) error {
+// reloadRules replaces the rules by the ones of the store, the current
+// rules are kept when they are invalid
+func (a *NotificationActor) reloadRules() error {
+	if a.store == nil {
+		return fmt.Errorf("no rule store")
+	}
+	rules, err := a.store.LoadRules()
+	if err != nil {
+		return err
+	}
+	return a.setRules(rules)
+}
+
+// setRules validates rules and replaces the current ones with a copy
+func (a *NotificationActor) setRules(rules []*proto.AlertRule) error {
+	ids := make(map[string]bool)
+	copies := make([]*proto.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		if err := ValidateAlertRule(rule); err != nil {
+			return err
+		}
+		if ids[rule.Id] {
+			return fmt.Errorf("duplicate rule %q", rule.Id)
+		}
+		ids[rule.Id] = true
+		for _, name := range rule.Notifiers {
+			if !slices.ContainsFunc(a.notifiers, func(n *notifierEntry) bool { return n.name == name }) {
+				return fmt.Errorf("rule %s: unknown notifier %q", rule.Id, name)
+			}
+		}
+		copies = append(copies, protobuf.Clone(rule).(*proto.AlertRule))
+	}
+	slices.SortFunc(copies, func(x, y *proto.AlertRule) int { return strings.Compare(x.Id, y.Id) })
+	a.rules = copies
 	return nil
 }
+
+// offer notifies about event unless the notifier is cooling down for the
+// camera, in which case the event is held back or dropped
+func (a *NotificationActor) offer(ctx *actor.ReceiveContext, key cooldownKey, event *proto.DetectionEvent, now time.Time) {
+	n := a.notifiers[key.notifier]
+	c, ok := a.cooldowns[key]
+	if !ok || !now.Before(c.until) && c.pending == nil {
+		a.notify(n, event)
+		a.startCooldown(ctx, key, now)
+		return
+	}
+	if !n.policy.Aggregate {
+		return
+	}
+	c.pending = aggregate(c.pending, event)
+}
+
+// startCooldown opens the cooldown window of a notifier for a camera. With
+// aggregation, the actor is told when it ends to send the events held back.
+func (a *NotificationActor) startCooldown(ctx *actor.ReceiveContext, key cooldownKey, now time.Time) {
+	policy := a.notifiers[key.notifier].policy
+	if policy.Cooldown <= 0 {
+		delete(a.cooldowns, key)
+		return
+	}
+	a.cooldowns[key] = &cooldown{until: now.Add(policy.Cooldown)}
+	if !policy.Aggregate {
+		return
+	}
+	self := ctx.Self()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.deliveries.Err() != nil {
+		// stopping
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(policy.Cooldown, func() {
+		a.mu.Lock()
+		delete(a.timers, timer)
+		a.mu.Unlock()
+		_ = self.Tell(context.Background(), self, &proto.NotificationCooldownEnded{CameraId: key.cameraID, Notifier: int32(key.notifier)})
+	})
+	a.timers[timer] = true
+}
+
+// endCooldown sends the events held back during the cooldown, which starts
+// again, or closes the window when there are none
+func (a *NotificationActor) endCooldown(ctx *actor.ReceiveContext, key cooldownKey, now time.Time) {
+	c, ok := a.cooldowns[key]
+	// a timer of a previous window may fire after the window started again
+	if !ok || now.Before(c.until) {
+		return
+	}
+	if c.pending == nil {
+		delete(a.cooldowns, key)
+		return
+	}
+	a.notify(a.notifiers[key.notifier], c.pending)
+	a.startCooldown(ctx, key, now)
+}
+
+// notify delivers event to the notifier in the background, within deliveryTimeout
+func (a *NotificationActor) notify(n *notifierEntry, event *proto.DetectionEvent) {
+	ctx, cancel := context.WithTimeout(a.deliveries, deliveryTimeout)
+	go func() {
+		defer cancel()
+		if err := n.notifier.Notify(ctx, event); err != nil {
+			log.Printf("NotificationActor: failed to notify %s: %v", n.name, err)
+		}
+	}()
+}
+
+// aggregate adds event to the events held back so far, pending when there
+// are none yet. The result is a copy of event, the latest one.
+func aggregate(pending *proto.DetectionEvent, event *proto.DetectionEvent) *proto.DetectionEvent {
+	agg := &proto.DetectionAggregate{Since: event.Timestamp}
+	if pending != nil {
+		agg = pending.Aggregate
+	}
+	agg.Events++
+	for _, d := range event.Detections {
+		if d.Label != "" && !slices.Contains(agg.Labels, d.Label) {
+			agg.Labels = append(agg.Labels, d.Label)
+		}
+	}
+	latest := protobuf.Clone(event).(*proto.DetectionEvent)
+	latest.Aggregate = agg
+	return latest
+}
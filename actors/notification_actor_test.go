@@ -0,0 +1,173 @@
+package actors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+	aktlog "github.com/tochemey/goakt/v3/log"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// recorder is a Notifier recording the events it is told about as
+// "camera", or "camera+N" for an aggregate of N events
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := event.CameraId
+	if event.Aggregate != nil {
+		name = fmt.Sprintf("%s+%d", name, event.Aggregate.Events)
+	}
+	r.events = append(r.events, name)
+	return nil
+}
+
+func (r *recorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := slices.Clone(r.events)
+	// the cooldowns of the cameras end in any order
+	slices.Sort(events)
+	return events
+}
+
+// spawn runs a in a new actor system, stopped at the end of the test
+func spawn(t *testing.T, a actor.Actor) *actor.PID {
+	t.Helper()
+	ctx := context.Background()
+	system, err := actor.NewActorSystem("test", actor.WithLogger(aktlog.DiscardLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := system.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = system.Stop(ctx) })
+	pid, err := system.Spawn(ctx, "NotificationActor", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pid
+}
+
+func TestNotificationCooldowns(t *testing.T) {
+	const cooldown = 200 * time.Millisecond
+	aggregated := NotificationPolicy{Cooldown: cooldown, Aggregate: true}
+	dropped := NotificationPolicy{Cooldown: cooldown}
+
+	// each step sends an event of each camera, waiting first
+	type step struct {
+		wait    time.Duration
+		cameras []string
+	}
+	tests := []struct {
+		name     string
+		policies []NotificationPolicy
+		steps    []step
+		want     [][]string // events told to each notifier
+	}{
+		{
+			name:     "no cooldown",
+			policies: []NotificationPolicy{{}},
+			steps:    []step{{cameras: []string{"a"}}, {cameras: []string{"a"}}, {cameras: []string{"a"}}},
+			want:     [][]string{{"a", "a", "a"}},
+		},
+		{
+			name:     "dropped during the cooldown",
+			policies: []NotificationPolicy{dropped},
+			steps:    []step{{cameras: []string{"a"}}, {cameras: []string{"a"}}, {cameras: []string{"a"}}},
+			want:     [][]string{{"a"}},
+		},
+		{
+			name:     "notified again once the cooldown expired",
+			policies: []NotificationPolicy{dropped},
+			steps:    []step{{cameras: []string{"a"}}, {cameras: []string{"a"}}, {wait: cooldown * 3 / 2, cameras: []string{"a"}}},
+			want:     [][]string{{"a", "a"}},
+		},
+		{
+			name:     "aggregated when the cooldown ends",
+			policies: []NotificationPolicy{aggregated},
+			steps:    []step{{cameras: []string{"a"}}, {cameras: []string{"a"}}, {cameras: []string{"a"}}},
+			want:     [][]string{{"a", "a+2"}},
+		},
+		{
+			name:     "a cooldown without events closes",
+			policies: []NotificationPolicy{aggregated},
+			steps:    []step{{cameras: []string{"a"}}, {wait: cooldown * 3 / 2, cameras: []string{"a"}}},
+			want:     [][]string{{"a", "a"}},
+		},
+		{
+			name:     "per camera",
+			policies: []NotificationPolicy{aggregated},
+			steps:    []step{{cameras: []string{"a", "b"}}, {cameras: []string{"a"}}, {cameras: []string{"a"}}},
+			want:     [][]string{{"a", "a+2", "b"}},
+		},
+		{
+			name:     "per notifier",
+			policies: []NotificationPolicy{{}, dropped, aggregated},
+			steps:    []step{{cameras: []string{"a", "b"}}, {cameras: []string{"a", "b"}}},
+			want:     [][]string{{"a", "a", "b", "b"}, {"a", "b"}, {"a", "a+1", "b", "b+1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := NewNotificationActor()
+			recorders := make([]*recorder, len(tt.policies))
+			for i, policy := range tt.policies {
+				recorders[i] = &recorder{}
+				a.WithNotifier(fmt.Sprintf("n%d", i), recorders[i], policy)
+			}
+			pid := spawn(t, a)
+
+			for _, s := range tt.steps {
+				time.Sleep(s.wait)
+				for _, camera := range s.cameras {
+					event := &proto.DetectionEvent{CameraId: camera, Timestamp: time.Now().UnixMilli(), Detections: []*proto.Detection{{Label: "person"}}}
+					if err := actor.Tell(context.Background(), pid, event); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			// let the last cooldowns end, and the ones started by their aggregates
+			time.Sleep(cooldown * 3)
+
+			for i, r := range recorders {
+				if got := r.recorded(); !slices.Equal(got, tt.want[i]) {
+					t.Errorf("notifier %d told about %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	event := func(ts int64, labels ...string) *proto.DetectionEvent {
+		e := &proto.DetectionEvent{CameraId: "a", Timestamp: ts}
+		for _, label := range labels {
+			e.Detections = append(e.Detections, &proto.Detection{Label: label})
+		}
+		return e
+	}
+	var pending *proto.DetectionEvent
+	for _, e := range []*proto.DetectionEvent{event(1, "person"), event(2, "car", "person"), event(3)} {
+		pending = aggregate(pending, e)
+	}
+	if pending.Timestamp != 3 {
+		t.Errorf("aggregate of the event at %d, want the latest at 3", pending.Timestamp)
+	}
+	agg := pending.Aggregate
+	if agg.Events != 3 || agg.Since != 1 || !slices.Equal(agg.Labels, []string{"person", "car"}) {
+		t.Errorf("got aggregate of %d events since %d with %v, want 3 since 1 with [person car]", agg.Events, agg.Since, agg.Labels)
+	}
+}
@@ -0,0 +1,68 @@
+package actors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/tracking"
+)
+
+// DefaultDetector is the detector run on cameras that don't pick any
+const DefaultDetector = "face"
+
+// DefaultProcessingConfig returns the processing settings used when none are given:
+// every frame goes through the DefaultDetector and detections are tracked
+func DefaultProcessingConfig() *proto.ProcessingConfig {
+	tracker := tracking.DefaultConfig()
+	return &proto.ProcessingConfig{
+		Detectors: []string{DefaultDetector},
+		Motion: &proto.MotionConfig{
+			Enabled:     false,
+			Sensitivity: 0.8,
+			MinArea:     500,
+		},
+		Tracking: &proto.TrackingConfig{
+			Enabled:      true,
+			IouThreshold: float32(tracker.IoUThreshold),
+			MaxDistance:  float32(tracker.MaxDistance),
+			MaxMissed:    int32(tracker.MaxMissed),
+		},
+	}
+}
+
+// ValidateProcessingConfig checks that a ProcessingConfig can be applied to a camera
+func ValidateProcessingConfig(c *proto.ProcessingConfig) error {
+	if c == nil {
+		return fmt.Errorf("missing processing config")
+	}
+	if m := c.Motion; m != nil {
+		if !finite(float64(m.Sensitivity)) || m.Sensitivity < 0 || m.Sensitivity > 1 {
+			return fmt.Errorf("motion sensitivity must be in [0, 1], got %g", m.Sensitivity)
+		}
+		if m.MinArea < 0 {
+			return fmt.Errorf("motion min area must not be negative, got %d", m.MinArea)
+		}
+	}
+	if t := c.Tracking; t != nil {
+		if !finite(float64(t.IouThreshold)) || t.IouThreshold < 0 || t.IouThreshold > 1 {
+			return fmt.Errorf("tracking IoU threshold must be in [0, 1], got %g", t.IouThreshold)
+		}
+		if !finite(float64(t.MaxDistance)) || t.MaxDistance < 0 {
+			return fmt.Errorf("tracking max distance must be a non negative number, got %g", t.MaxDistance)
+		}
+		if t.MaxMissed < 0 {
+			return fmt.Errorf("tracking max missed frames must not be negative, got %d", t.MaxMissed)
+		}
+	}
+	if err := ValidateTripwires(c.Tripwires); err != nil {
+		return err
+	}
+	return ValidateZones(c.Zones)
+}
+
+// finite tells whether f is a number that can be range checked, NaN fails
+// every comparison
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
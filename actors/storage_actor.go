@@ -14,8 +14,14 @@ type StorageBackend interface {
 	SaveClip(ctx context.Context, cameraID string, timestamp time.Time, imageClip []byte) error
 }
 
+// TrackStore is implemented by the backends recording track events
+type TrackStore interface {
+	SaveTrackEvent(ctx context.Context, event *proto.TrackEvent) error
+}
+
 type StorageActor struct {
 	backend StorageBackend
+	filter  DetectionFilter
 }
 
 var _ actor.Actor = (*StorageActor)(nil)
@@ -24,17 +30,30 @@ func NewStorageActor(backend StorageBackend) *StorageActor {
 	return &StorageActor{backend: backend}
 }
 
+// WithFilter only stores the detections matching filter
+func (a *StorageActor) WithFilter(filter DetectionFilter) *StorageActor {
+	a.filter = filter
+	return a
+}
+
 func (a *StorageActor) PreStart(ctx *actor.Context) error {
 	return nil
 }
 
 func (a *StorageActor) Receive(ctx *actor.ReceiveContext) {
 	msg := ctx.Message()
+	if track, ok := msg.(*proto.TrackEvent); ok {
+		a.saveTrackEvent(ctx, track)
+		return
+	}
 	event, ok := msg.(*proto.DetectionEvent)
 	if !ok {
 		ctx.Unhandled()
 		return
 	}
+	if event = a.filter.Apply(event); event == nil {
+		return
+	}
 
 	ts := time.UnixMilli(event.Timestamp)
 	if err := a.backend.SaveMetadata(ctx.Context(), event.CameraId, ts, event.Detections); err != nil {
@@ -46,6 +65,16 @@ func (a *StorageActor) Receive(ctx *actor.ReceiveContext) {
 	}
 }
 
+func (a *StorageActor) saveTrackEvent(ctx *actor.ReceiveContext, event *proto.TrackEvent) {
+	store, ok := a.backend.(TrackStore)
+	if !ok {
+		return
+	}
+	if err := store.SaveTrackEvent(ctx.Context(), event); err != nil {
+		log.Printf("StorageActor: failed to save track event for camera %s: %v", event.CameraId, err)
+	}
+}
+
 func (a *StorageActor) PostStop(ctx *actor.Context) error {
 	type closer interface {
 		Close() error
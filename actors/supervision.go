@@ -0,0 +1,105 @@
+package actors
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// RestartPolicy controls how a parent restarts a failing child
+type RestartPolicy struct {
+	InitialBackoff time.Duration // wait before the first restart
+	MaxBackoff     time.Duration // upper bound of the exponential backoff
+	Multiplier     float64       // backoff growth factor between restarts
+	MaxRestarts    int           // failures in a row before the child is stopped, 0 restarts forever
+	ResetAfter     time.Duration // running that long without failing resets the count
+}
+
+// DefaultProcessorRestartPolicy always restarts a processor, with a backoff from 100ms to 30s
+var DefaultProcessorRestartPolicy = RestartPolicy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	ResetAfter:     time.Minute,
+}
+
+// DefaultCameraRestartPolicy restarts a camera with a backoff from 1s to 1m
+// and stops it after 5 failures in a row
+var DefaultCameraRestartPolicy = RestartPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+	Multiplier:     2,
+	MaxRestarts:    5,
+	ResetAfter:     10 * time.Minute,
+}
+
+// escalate makes a child report all its failures, panics included, to its
+// parent with a Mayday. The parent decides what to do following its policy.
+func escalate() actor.SpawnOption {
+	return actor.WithSupervisor(actor.NewSupervisor(actor.WithAnyErrorDirective(actor.EscalateDirective)))
+}
+
+// childFailures tracks the failures of a supervised child
+type childFailures struct {
+	inRow       int // failures without ResetAfter of running in between
+	restarts    int32
+	lastFailure string
+	lastAt      time.Time
+	stopped     bool
+}
+
+// fail records a failure and returns how long to wait before restarting
+// the child, false when the child must stay stopped
+func (f *childFailures) fail(policy RestartPolicy, reason string, now time.Time) (time.Duration, bool) {
+	if !f.lastAt.IsZero() && policy.ResetAfter > 0 && now.Sub(f.lastAt) >= policy.ResetAfter {
+		f.inRow = 0
+	}
+	f.inRow++
+	f.lastFailure = reason
+	f.lastAt = now
+	if policy.MaxRestarts > 0 && f.inRow > policy.MaxRestarts {
+		f.stopped = true
+		return 0, false
+	}
+	return backoff(policy.InitialBackoff, policy.MaxBackoff, policy.Multiplier, f.inRow), true
+}
+
+// scheduleRestart sends RestartChild to the parent once the backoff is over
+func scheduleRestart(parent *actor.PID, name string, wait time.Duration) {
+	time.AfterFunc(wait, func() {
+		// fails when the parent stopped in the meantime, the child is gone too
+		_ = parent.Tell(context.Background(), parent, &proto.RestartChild{Name: name})
+	})
+}
+
+// publishLifecycle sends a LifecycleEvent to the bus, if any
+func publishLifecycle(ctx context.Context, bus *actor.PID, event *proto.LifecycleEvent) {
+	event.Timestamp = time.Now().UnixMilli()
+	log.Printf("%s %s: %s (%d restarts)", event.Actor, lifecycleLabel(event.Type), event.Reason, event.Restarts)
+	if bus == nil {
+		return
+	}
+	if err := actor.Tell(ctx, bus, event); err != nil {
+		log.Printf("failed to publish lifecycle event of %s: %v", event.Actor, err)
+	}
+}
+
+func lifecycleLabel(t proto.LifecycleEventType) string {
+	switch t {
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_FAILED:
+		return "failed"
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_RESTARTED:
+		return "restarted"
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_STOPPED:
+		return "stopped"
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED:
+		return "removed"
+	case proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPDATED:
+		return "updated"
+	}
+	return "unknown"
+}
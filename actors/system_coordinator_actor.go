@@ -1,28 +1,507 @@
 package actors
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/tochemey/goakt/v3/actor"
+	"github.com/tochemey/goakt/v3/goaktpb"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/live"
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/source"
 )
 
-// SystemCoordinatorActor manages system state and orchestration
-// (Coordination logic will be added later)
-type SystemCoordinatorActor struct{}
+// statusTimeout bounds how long the coordinator waits for the status of its
+// cameras, queried all at once
+const statusTimeout = time.Second
+
+// CameraStore persists the cameras of the SystemCoordinatorActor
+type CameraStore interface {
+	LoadCameras() ([]*proto.CameraSpec, error)
+	SaveCameras(cameras []*proto.CameraSpec) error
+}
+
+// SystemCoordinatorActor owns the camera registry. It answers AddCamera,
+// RemoveCamera, ListCameras and GetCamera and runs every camera as a
+// CameraFeedActor child named after the camera ID. A failing camera is
+// restarted following the coordinator's RestartPolicy.
+// With a CameraStore, the cameras are saved on every change and started
+// again when the coordinator starts.
+type SystemCoordinatorActor struct {
+	processor *actor.PID
+	detectors *detection.Registry
+	hub       *live.Hub
+	policy    RestartPolicy
+	bus       *actor.PID
+	store     CameraStore
+
+	cameras map[string]*cameraEntry
+}
+
+type cameraEntry struct {
+	spec     *proto.CameraSpec
+	feed     *CameraFeedActor // spawned again on restart, keeping its settings
+	failures childFailures
+}
 
 var _ actor.Actor = (*SystemCoordinatorActor)(nil)
 
-func NewSystemCoordinatorActor() *SystemCoordinatorActor {
-	return &SystemCoordinatorActor{}
+// NewSystemCoordinatorActor creates a coordinator sending the frames of its
+// cameras to processor. The detectors registry validates the detector names
+// cameras ask for.
+func NewSystemCoordinatorActor(processor *actor.PID, detectors *detection.Registry) *SystemCoordinatorActor {
+	return &SystemCoordinatorActor{
+		processor: processor,
+		detectors: detectors,
+		policy:    DefaultCameraRestartPolicy,
+	}
+}
+
+// WithRestartPolicy overrides the DefaultCameraRestartPolicy
+func (a *SystemCoordinatorActor) WithRestartPolicy(policy RestartPolicy) *SystemCoordinatorActor {
+	a.policy = policy
+	return a
+}
+
+// WithEventBus publishes the failures and restarts of the cameras as LifecycleEvents
+func (a *SystemCoordinatorActor) WithEventBus(bus *actor.PID) *SystemCoordinatorActor {
+	a.bus = bus
+	return a
+}
+
+// WithCameraStore saves the cameras to store and starts the saved ones
+func (a *SystemCoordinatorActor) WithCameraStore(store CameraStore) *SystemCoordinatorActor {
+	a.store = store
+	return a
+}
+
+// WithLiveHub publishes the frames of the cameras to the hub
+func (a *SystemCoordinatorActor) WithLiveHub(hub *live.Hub) *SystemCoordinatorActor {
+	a.hub = hub
+	return a
 }
 
 func (a *SystemCoordinatorActor) PreStart(ctx *actor.Context) error {
+	a.cameras = make(map[string]*cameraEntry)
 	return nil
 }
 
 func (a *SystemCoordinatorActor) Receive(ctx *actor.ReceiveContext) {
-	// TODO: Implement coordination logic
-	ctx.Unhandled()
+	switch msg := ctx.Message().(type) {
+	case *goaktpb.PostStart:
+		a.loadCameras(ctx)
+	case *proto.AddCamera:
+		if err := a.addCamera(ctx, msg.Camera); err != nil {
+			log.Printf("SystemCoordinatorActor: failed to add camera: %v", err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		a.saveCameras()
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.UpdateCamera:
+		if err := a.updateCamera(ctx, msg.Camera); err != nil {
+			log.Printf("SystemCoordinatorActor: failed to update camera: %v", err)
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		a.saveCameras()
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.RemoveCamera:
+		if err := a.removeCamera(ctx, msg.CameraId); err != nil {
+			ctx.Response(&proto.CommandResult{Error: err.Error()})
+			return
+		}
+		a.saveCameras()
+		ctx.Response(&proto.CommandResult{Ok: true})
+	case *proto.ListCameras:
+		cameras := make([]*cameraEntry, 0, len(a.cameras))
+		for _, camera := range a.cameras {
+			cameras = append(cameras, camera)
+		}
+		list := &proto.CameraList{Cameras: a.cameraInfos(ctx, cameras)}
+		sort.Slice(list.Cameras, func(i, j int) bool { return list.Cameras[i].CameraId < list.Cameras[j].CameraId })
+		ctx.Response(list)
+	case *proto.GetCamera:
+		camera, ok := a.cameras[msg.CameraId]
+		if !ok {
+			ctx.Response(&proto.CommandResult{Error: fmt.Sprintf("unknown camera %q", msg.CameraId)})
+			return
+		}
+		ctx.Response(a.cameraInfos(ctx, []*cameraEntry{camera})[0])
+	case *goaktpb.Mayday:
+		a.fail(ctx, ctx.Sender(), msg.Reason)
+	case *proto.RestartChild:
+		a.restart(ctx, msg.Name)
+	default:
+		ctx.Unhandled()
+	}
 }
 
 func (a *SystemCoordinatorActor) PostStop(ctx *actor.Context) error {
+	// the cameras are children, goakt stops them before their parent
+	if a.hub != nil {
+		for cameraID := range a.cameras {
+			a.hub.Remove(cameraID)
+		}
+	}
+	a.cameras = nil
+	return nil
+}
+
+// ValidateCameraSpec checks that a camera can be started from spec
+func ValidateCameraSpec(spec *proto.CameraSpec) error {
+	if spec == nil || spec.CameraId == "" {
+		return fmt.Errorf("camera id is empty")
+	}
+	if _, err := source.Parse(spec.Source); err != nil {
+		return err
+	}
+	if spec.Capture != nil {
+		if err := ValidateCaptureConfig(spec.Capture); err != nil {
+			return err
+		}
+	}
+	if spec.Processing != nil {
+		if err := ValidateProcessingConfig(spec.Processing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *SystemCoordinatorActor) addCamera(ctx *actor.ReceiveContext, spec *proto.CameraSpec) error {
+	if err := ValidateCameraSpec(spec); err != nil {
+		return err
+	}
+	if _, ok := a.cameras[spec.CameraId]; ok {
+		return fmt.Errorf("camera %q already exists", spec.CameraId)
+	}
+	// actor names are unique in the whole system
+	if _, err := ctx.ActorSystem().LocalActor(spec.CameraId); err == nil {
+		return fmt.Errorf("camera id %q is already used by another actor", spec.CameraId)
+	}
+	if a.detectors != nil && spec.Processing != nil {
+		if err := a.detectors.Validate(spec.Processing.Detectors); err != nil {
+			return err
+		}
+	}
+
+	spec = protobuf.Clone(spec).(*proto.CameraSpec)
+	if spec.Capture == nil {
+		spec.Capture = DefaultCaptureConfig()
+	}
+	if spec.Processing == nil {
+		spec.Processing = DefaultProcessingConfig()
+	}
+	feed, err := a.spawnCamera(ctx, spec)
+	if err != nil {
+		return err
+	}
+	a.cameras[spec.CameraId] = &cameraEntry{spec: spec, feed: feed}
+	log.Printf("SystemCoordinatorActor: added camera %s (%s)", spec.CameraId, source.Redact(spec.Source))
 	return nil
 }
+
+// spawnCamera opens the source of spec and starts its CameraFeedActor.
+// The source descriptor of spec is normalized.
+func (a *SystemCoordinatorActor) spawnCamera(ctx *actor.ReceiveContext, spec *proto.CameraSpec) (*CameraFeedActor, error) {
+	feed, err := a.newCamera(spec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ctx.Self().SpawnChild(ctx.Context(), spec.CameraId, feed, escalate()); err != nil {
+		return nil, fmt.Errorf("spawn camera %s: %w", spec.CameraId, err)
+	}
+	return feed, nil
+}
+
+// newCamera opens the source of spec and creates its CameraFeedActor,
+// without starting it. The source descriptor of spec is normalized.
+func (a *SystemCoordinatorActor) newCamera(spec *proto.CameraSpec) (*CameraFeedActor, error) {
+	desc, err := source.Parse(spec.Source)
+	if err != nil {
+		return nil, err
+	}
+	frameSource, err := source.New(desc)
+	if err != nil {
+		return nil, err
+	}
+	spec.Source = desc.String()
+
+	return NewCameraFeedActorWithConfig(spec.CameraId, frameSource, spec.Capture, a.processor).
+		WithProcessingConfig(spec.Processing).
+		WithLiveHub(a.hub), nil
+}
+
+// updateCamera applies new settings to a camera. A camera whose source
+// changed, or which was stopped after failing, is started again.
+func (a *SystemCoordinatorActor) updateCamera(ctx *actor.ReceiveContext, spec *proto.CameraSpec) error {
+	if spec == nil {
+		return fmt.Errorf("camera is empty")
+	}
+	camera, ok := a.cameras[spec.CameraId]
+	if !ok {
+		return fmt.Errorf("unknown camera %q", spec.CameraId)
+	}
+	spec = protobuf.Clone(spec).(*proto.CameraSpec)
+	if spec.Source == "" {
+		spec.Source = camera.spec.Source
+	}
+	if spec.Capture == nil {
+		spec.Capture = camera.spec.Capture
+	}
+	if spec.Processing == nil {
+		spec.Processing = camera.spec.Processing
+	}
+	if err := ValidateCameraSpec(spec); err != nil {
+		return err
+	}
+	if a.detectors != nil {
+		if err := a.detectors.Validate(spec.Processing.Detectors); err != nil {
+			return err
+		}
+	}
+	desc, err := source.Parse(spec.Source)
+	if err != nil {
+		return err
+	}
+	spec.Source = desc.String()
+
+	pid, err := ctx.Self().Child(spec.CameraId)
+	if err != nil {
+		// start the camera anew, it was stopped or waits for a restart
+		return a.replaceCamera(ctx, camera, spec, nil)
+	}
+	if spec.Source != camera.spec.Source {
+		return a.replaceCamera(ctx, camera, spec, pid)
+	}
+
+	// the settings were validated like the camera does, it can't reject them
+	// and the coordinator doesn't wait for it
+	for _, msg := range []protobuf.Message{&proto.UpdateCaptureConfig{Config: spec.Capture}, &proto.UpdateProcessingConfig{Config: spec.Processing}} {
+		if err := actor.Tell(ctx.Context(), pid, msg); err != nil {
+			return fmt.Errorf("update camera %s: %w", spec.CameraId, err)
+		}
+	}
+	camera.spec = spec
+	log.Printf("SystemCoordinatorActor: updated camera %s", spec.CameraId)
+	a.publishUpdate(ctx, spec)
+	return nil
+}
+
+// replaceCamera starts the camera anew with spec, stopping running first
+// when the camera runs. The new camera is created before running is stopped,
+// and the old one is started again when the new one fails to spawn.
+func (a *SystemCoordinatorActor) replaceCamera(ctx *actor.ReceiveContext, camera *cameraEntry, spec *proto.CameraSpec, running *actor.PID) error {
+	feed, err := a.newCamera(spec)
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		if err := running.Shutdown(ctx.Context()); err != nil {
+			return fmt.Errorf("stop camera %s: %w", spec.CameraId, err)
+		}
+	}
+	if _, err := ctx.Self().SpawnChild(ctx.Context(), spec.CameraId, feed, escalate()); err != nil {
+		err = fmt.Errorf("spawn camera %s: %w", spec.CameraId, err)
+		if running != nil {
+			if _, restoreErr := ctx.Self().SpawnChild(ctx.Context(), spec.CameraId, camera.feed, escalate()); restoreErr != nil {
+				// leave it to the restart policy
+				a.failed(ctx, camera, fmt.Sprintf("restore after failed update: %v", restoreErr))
+			}
+		}
+		return err
+	}
+	a.cameras[spec.CameraId] = &cameraEntry{spec: spec, feed: feed}
+	log.Printf("SystemCoordinatorActor: restarted camera %s (%s) with new settings", spec.CameraId, source.Redact(spec.Source))
+	a.publishUpdate(ctx, spec)
+	return nil
+}
+
+// publishUpdate tells the subscribers of the lifecycle events, e.g. the
+// AnalyticsActor, the zones and tripwires of an updated camera
+func (a *SystemCoordinatorActor) publishUpdate(ctx *actor.ReceiveContext, spec *proto.CameraSpec) {
+	event := &proto.LifecycleEvent{
+		Actor:    spec.CameraId,
+		Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPDATED,
+		CameraId: spec.CameraId,
+	}
+	for _, z := range spec.Processing.GetZones() {
+		event.Zones = append(event.Zones, z.Name)
+	}
+	for _, t := range spec.Processing.GetTripwires() {
+		event.Tripwires = append(event.Tripwires, t.Name)
+	}
+	publishLifecycle(ctx.Context(), a.bus, event)
+}
+
+// loadCameras starts the cameras saved in the store
+func (a *SystemCoordinatorActor) loadCameras(ctx *actor.ReceiveContext) {
+	if a.store == nil {
+		return
+	}
+	specs, err := a.store.LoadCameras()
+	if err != nil {
+		log.Printf("SystemCoordinatorActor: failed to load cameras: %v", err)
+		return
+	}
+	for _, spec := range specs {
+		if err := a.addCamera(ctx, spec); err != nil {
+			log.Printf("SystemCoordinatorActor: failed to start saved camera %s: %v", spec.GetCameraId(), err)
+		}
+	}
+}
+
+// saveCameras writes the cameras to the store, sorted by ID
+func (a *SystemCoordinatorActor) saveCameras() {
+	if a.store == nil {
+		return
+	}
+	specs := make([]*proto.CameraSpec, 0, len(a.cameras))
+	for _, camera := range a.cameras {
+		specs = append(specs, camera.spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].CameraId < specs[j].CameraId })
+	if err := a.store.SaveCameras(specs); err != nil {
+		log.Printf("SystemCoordinatorActor: failed to save cameras: %v", err)
+	}
+}
+
+func (a *SystemCoordinatorActor) removeCamera(ctx *actor.ReceiveContext, cameraID string) error {
+	if _, ok := a.cameras[cameraID]; !ok {
+		return fmt.Errorf("unknown camera %q", cameraID)
+	}
+	delete(a.cameras, cameraID)
+	if pid, err := ctx.Self().Child(cameraID); err == nil {
+		if err := pid.Shutdown(ctx.Context()); err != nil {
+			log.Printf("SystemCoordinatorActor: failed to stop camera %s: %v", cameraID, err)
+		}
+	}
+	if a.hub != nil {
+		a.hub.Remove(cameraID)
+	}
+	log.Printf("SystemCoordinatorActor: removed camera %s", cameraID)
+	publishLifecycle(ctx.Context(), a.bus, &proto.LifecycleEvent{
+		Actor:    cameraID,
+		Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_REMOVED,
+		CameraId: cameraID,
+	})
+	return nil
+}
+
+// fail stops the failing camera, it is restarted once the backoff is over
+func (a *SystemCoordinatorActor) fail(ctx *actor.ReceiveContext, pid *actor.PID, reason string) {
+	if pid == nil {
+		return
+	}
+	camera, ok := a.cameras[pid.Name()]
+	if !ok {
+		return
+	}
+	// the failed camera is suspended, stop it until its restart
+	if err := pid.Shutdown(ctx.Context()); err != nil {
+		log.Printf("SystemCoordinatorActor: failed to stop camera %s: %v", pid.Name(), err)
+	}
+	a.failed(ctx, camera, reason)
+}
+
+// failed records the failure of a camera and schedules its restart, unless
+// it failed too often
+func (a *SystemCoordinatorActor) failed(ctx *actor.ReceiveContext, camera *cameraEntry, reason string) {
+	wait, restart := camera.failures.fail(a.policy, reason, time.Now())
+	event := &proto.LifecycleEvent{
+		Actor:    camera.spec.CameraId,
+		Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_FAILED,
+		Reason:   reason,
+		Restarts: camera.failures.restarts,
+		CameraId: camera.spec.CameraId,
+	}
+	if !restart {
+		event.Type = proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_STOPPED
+	}
+	publishLifecycle(ctx.Context(), a.bus, event)
+	if restart {
+		scheduleRestart(ctx.Self(), camera.spec.CameraId, wait)
+	}
+}
+
+// restart spawns the camera again, with the settings it had when it failed
+func (a *SystemCoordinatorActor) restart(ctx *actor.ReceiveContext, cameraID string) {
+	// the camera may have been removed during the backoff
+	camera, ok := a.cameras[cameraID]
+	if !ok || camera.failures.stopped {
+		return
+	}
+	// or started again by an update
+	if pid, err := ctx.Self().Child(cameraID); err == nil && pid.IsRunning() {
+		return
+	}
+	if _, err := ctx.Self().SpawnChild(ctx.Context(), cameraID, camera.feed, escalate()); err != nil {
+		a.failed(ctx, camera, fmt.Sprintf("restart: %v", err))
+		return
+	}
+	camera.failures.restarts++
+	publishLifecycle(ctx.Context(), a.bus, &proto.LifecycleEvent{
+		Actor:    cameraID,
+		Type:     proto.LifecycleEventType_LIFECYCLE_EVENT_TYPE_RESTARTED,
+		Reason:   camera.failures.lastFailure,
+		Restarts: camera.failures.restarts,
+		CameraId: cameraID,
+	})
+}
+
+// cameraInfos describes cameras with their current status, left unset when
+// a camera doesn't answer. The cameras are queried concurrently, within
+// statusTimeout overall.
+func (a *SystemCoordinatorActor) cameraInfos(ctx *actor.ReceiveContext, cameras []*cameraEntry) []*proto.CameraInfo {
+	queryCtx, cancel := context.WithTimeout(ctx.Context(), statusTimeout)
+	defer cancel()
+
+	infos := make([]*proto.CameraInfo, len(cameras))
+	var wg sync.WaitGroup
+	for i, camera := range cameras {
+		infos[i] = cameraInfo(camera)
+		pid, err := ctx.Self().Child(camera.spec.CameraId)
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(info *proto.CameraInfo) {
+			defer wg.Done()
+			resp, err := actor.Ask(queryCtx, pid, &proto.GetCameraStatus{}, statusTimeout)
+			if err != nil {
+				log.Printf("SystemCoordinatorActor: failed to get status of camera %s: %v", info.CameraId, err)
+				return
+			}
+			if status, ok := resp.(*proto.CameraStatus); ok {
+				info.Status = status
+			}
+		}(infos[i])
+	}
+	wg.Wait()
+	return infos
+}
+
+// cameraInfo describes a camera from its registry entry, without its status
+func cameraInfo(camera *cameraEntry) *proto.CameraInfo {
+	spec := camera.spec
+	info := &proto.CameraInfo{
+		CameraId:    spec.CameraId,
+		Source:      spec.Source,
+		Spec:        spec,
+		Restarts:    camera.failures.restarts,
+		LastFailure: camera.failures.lastFailure,
+		Stopped:     camera.failures.stopped,
+	}
+	if !camera.failures.lastAt.IsZero() {
+		info.LastFailureAt = camera.failures.lastAt.UnixMilli()
+	}
+	return info
+}
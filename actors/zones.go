@@ -0,0 +1,160 @@
+package actors
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// ValidateZones checks that zones can be applied to a camera
+func ValidateZones(zones []*proto.Zone) error {
+	names := make(map[string]bool, len(zones))
+	for _, z := range zones {
+		if z.Name == "" {
+			return fmt.Errorf("zone name is empty")
+		}
+		if names[z.Name] {
+			return fmt.Errorf("duplicate zone %q", z.Name)
+		}
+		names[z.Name] = true
+		if z.Type != proto.ZoneType_ZONE_TYPE_INCLUDE && z.Type != proto.ZoneType_ZONE_TYPE_EXCLUDE {
+			return fmt.Errorf("zone %q must be include or exclude", z.Name)
+		}
+		if len(z.Points) < 3 {
+			return fmt.Errorf("zone %q needs at least 3 points, got %d", z.Name, len(z.Points))
+		}
+		for _, p := range z.Points {
+			if !inFrame(p) {
+				return fmt.Errorf("zone %q has point (%g, %g) outside of the frame, coordinates are in [0, 1]", z.Name, p.X, p.Y)
+			}
+		}
+	}
+	return nil
+}
+
+// inFrame tells whether p is a point of the frame, in coordinates from 0 to 1
+func inFrame(p *proto.Point) bool {
+	return finite(float64(p.X)) && finite(float64(p.Y)) && p.X >= 0 && p.X <= 1 && p.Y >= 0 && p.Y <= 1
+}
+
+// polygon is a zone in the pixel coordinates of a frame
+type polygon struct {
+	name    string
+	exclude bool
+	points  []image.Point
+}
+
+// framePolygons scales the zones to a frame of the given size
+func framePolygons(zones []*proto.Zone, size image.Point) []polygon {
+	polygons := make([]polygon, 0, len(zones))
+	for _, z := range zones {
+		points := make([]image.Point, len(z.Points))
+		for i, p := range z.Points {
+			points[i] = image.Pt(int(p.X*float32(size.X)), int(p.Y*float32(size.Y)))
+		}
+		polygons = append(polygons, polygon{name: z.Name, exclude: z.Type == proto.ZoneType_ZONE_TYPE_EXCLUDE, points: points})
+	}
+	return polygons
+}
+
+// applyZones drops the results outside of the include zones or centered in an
+// exclude zone, and returns the names of the zones each kept result intersects
+func applyZones(results []detection.Result, polygons []polygon) ([]detection.Result, [][]string) {
+	if len(polygons) == 0 {
+		return results, make([][]string, len(results))
+	}
+	hasInclude := false
+	for _, p := range polygons {
+		hasInclude = hasInclude || !p.exclude
+	}
+
+	kept := make([]detection.Result, 0, len(results))
+	var names [][]string
+	for _, res := range results {
+		center := image.Pt((res.Box.Min.X+res.Box.Max.X)/2, (res.Box.Min.Y+res.Box.Max.Y)/2)
+		var in []string
+		included, excluded := !hasInclude, false
+		for _, p := range polygons {
+			if p.exclude && p.contains(center) {
+				excluded = true
+				break
+			}
+			if p.intersects(res.Box) {
+				in = append(in, p.name)
+				included = included || !p.exclude
+			}
+		}
+		if included && !excluded {
+			kept = append(kept, res)
+			names = append(names, in)
+		}
+	}
+	return kept, names
+}
+
+// contains tells whether pt is inside the polygon, by ray casting
+func (p polygon) contains(pt image.Point) bool {
+	inside := false
+	for i, j := 0, len(p.points)-1; i < len(p.points); j, i = i, i+1 {
+		a, b := p.points[i], p.points[j]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) &&
+			pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// intersects tells whether the polygon and the rectangle overlap
+func (p polygon) intersects(r image.Rectangle) bool {
+	if len(p.points) == 0 || r.Empty() {
+		return false
+	}
+	// one contains a point of the other
+	if p.points[0].In(r) || p.contains(r.Min) {
+		return true
+	}
+	// or their edges cross
+	corners := []image.Point{r.Min, image.Pt(r.Max.X, r.Min.Y), r.Max, image.Pt(r.Min.X, r.Max.Y)}
+	for i, j := 0, len(p.points)-1; i < len(p.points); j, i = i, i+1 {
+		for k := range corners {
+			if segmentsCross(p.points[j], p.points[i], corners[k], corners[(k+1)%len(corners)]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsCross tells whether the segments ab and cd intersect
+func segmentsCross(a, b, c, d image.Point) bool {
+	d1, d2 := orientation(c, d, a), orientation(c, d, b)
+	d3, d4 := orientation(a, b, c), orientation(a, b, d)
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return d1 == 0 && onSegment(c, d, a) ||
+		d2 == 0 && onSegment(c, d, b) ||
+		d3 == 0 && onSegment(a, b, c) ||
+		d4 == 0 && onSegment(a, b, d)
+}
+
+// orientation is the sign of the cross product of ab and ac
+func orientation(a, b, c image.Point) int {
+	v := int64(b.X-a.X)*int64(c.Y-a.Y) - int64(b.Y-a.Y)*int64(c.X-a.X)
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+// onSegment tells whether c, collinear with ab, lies between a and b
+func onSegment(a, b, c image.Point) bool {
+	return min(a.X, b.X) <= c.X && c.X <= max(a.X, b.X) &&
+		min(a.Y, b.Y) <= c.Y && c.Y <= max(a.Y, b.Y)
+}
@@ -0,0 +1,154 @@
+package actors
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// square is a polygon from (x, y) to (x+size, y+size)
+func square(name string, exclude bool, x, y, size int) polygon {
+	return polygon{name: name, exclude: exclude, points: []image.Point{
+		image.Pt(x, y), image.Pt(x+size, y), image.Pt(x+size, y+size), image.Pt(x, y+size),
+	}}
+}
+
+func TestPolygonContains(t *testing.T) {
+	triangle := polygon{name: "triangle", points: []image.Point{image.Pt(0, 0), image.Pt(100, 0), image.Pt(0, 100)}}
+	tests := []struct {
+		name    string
+		polygon polygon
+		point   image.Point
+		want    bool
+	}{
+		{name: "inside square", polygon: square("s", false, 0, 0, 100), point: image.Pt(50, 50), want: true},
+		{name: "outside square", polygon: square("s", false, 0, 0, 100), point: image.Pt(150, 50), want: false},
+		{name: "above square", polygon: square("s", false, 0, 0, 100), point: image.Pt(50, -1), want: false},
+		{name: "inside triangle", polygon: triangle, point: image.Pt(20, 20), want: true},
+		{name: "beyond the hypotenuse", polygon: triangle, point: image.Pt(60, 60), want: false},
+		{name: "no points", polygon: polygon{}, point: image.Pt(0, 0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.contains(tt.point); got != tt.want {
+				t.Errorf("contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygonIntersects(t *testing.T) {
+	zone := square("z", false, 100, 100, 100)
+	tests := []struct {
+		name string
+		rect image.Rectangle
+		want bool
+	}{
+		{name: "inside", rect: image.Rect(120, 120, 140, 140), want: true},
+		{name: "containing the zone", rect: image.Rect(0, 0, 300, 300), want: true},
+		{name: "overlapping an edge", rect: image.Rect(50, 120, 110, 140), want: true},
+		{name: "disjoint", rect: image.Rect(0, 0, 50, 50), want: false},
+		{name: "empty", rect: image.Rectangle{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zone.intersects(tt.rect); got != tt.want {
+				t.Errorf("intersects(%v) = %v, want %v", tt.rect, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyZones(t *testing.T) {
+	result := func(x, y int) detection.Result {
+		return detection.Result{Label: "person", Box: image.Rect(x, y, x+20, y+20)}
+	}
+	tests := []struct {
+		name      string
+		zones     []polygon
+		results   []detection.Result
+		wantKept  int
+		wantZones [][]string
+	}{
+		{
+			name:      "no zones keeps everything",
+			results:   []detection.Result{result(0, 0)},
+			wantKept:  1,
+			wantZones: [][]string{nil},
+		},
+		{
+			name:      "outside of the include zones",
+			zones:     []polygon{square("door", false, 100, 100, 100)},
+			results:   []detection.Result{result(0, 0), result(140, 140)},
+			wantKept:  1,
+			wantZones: [][]string{{"door"}},
+		},
+		{
+			name:      "centered in an exclude zone",
+			zones:     []polygon{square("tv", true, 0, 0, 100)},
+			results:   []detection.Result{result(40, 40), result(200, 200)},
+			wantKept:  1,
+			wantZones: [][]string{nil},
+		},
+		{
+			name:      "exclude zones only tag the results they touch",
+			zones:     []polygon{square("tv", true, 0, 0, 100)},
+			results:   []detection.Result{result(95, 50)},
+			wantKept:  1,
+			wantZones: [][]string{{"tv"}},
+		},
+		{
+			name:      "in several zones",
+			zones:     []polygon{square("door", false, 0, 0, 100), square("hall", false, 90, 0, 100)},
+			results:   []detection.Result{result(85, 10)},
+			wantKept:  1,
+			wantZones: [][]string{{"door", "hall"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, zones := applyZones(tt.results, tt.zones)
+			if len(kept) != tt.wantKept {
+				t.Fatalf("kept %d results, want %d", len(kept), tt.wantKept)
+			}
+			for i := range zones {
+				if !slices.Equal(zones[i], tt.wantZones[i]) {
+					t.Errorf("result %d in zones %v, want %v", i, zones[i], tt.wantZones[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateZones(t *testing.T) {
+	nan, inf := float32(math.NaN()), float32(math.Inf(1))
+	triangle := []*proto.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}}
+	tests := []struct {
+		name    string
+		zones   []*proto.Zone
+		wantErr bool
+	}{
+		{name: "valid", zones: []*proto.Zone{{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: triangle}}},
+		{name: "no name", zones: []*proto.Zone{{Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: triangle}}, wantErr: true},
+		{name: "duplicate", zones: []*proto.Zone{
+			{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: triangle},
+			{Name: "a", Type: proto.ZoneType_ZONE_TYPE_EXCLUDE, Points: triangle},
+		}, wantErr: true},
+		{name: "no type", zones: []*proto.Zone{{Name: "a", Points: triangle}}, wantErr: true},
+		{name: "two points", zones: []*proto.Zone{{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: triangle[:2]}}, wantErr: true},
+		{name: "outside of the frame", zones: []*proto.Zone{{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: append([]*proto.Point{{X: 2, Y: 0}}, triangle[1:]...)}}, wantErr: true},
+		{name: "NaN coordinate", zones: []*proto.Zone{{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: append([]*proto.Point{{X: nan, Y: 0}}, triangle[1:]...)}}, wantErr: true},
+		{name: "infinite coordinate", zones: []*proto.Zone{{Name: "a", Type: proto.ZoneType_ZONE_TYPE_INCLUDE, Points: append([]*proto.Point{{X: 0, Y: inf}}, triangle[1:]...)}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateZones(tt.zones); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateZones() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
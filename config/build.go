@@ -0,0 +1,176 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/notification"
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/storage"
+)
+
+// Backend is a storage backend of the StorageActor, closed on shutdown
+type Backend interface {
+	actors.StorageBackend
+	Close() error
+}
+
+// NewDetectorRegistry loads the detectors. Optional detectors failing to
+// load are skipped, the cameras listing them run without them.
+func (c *Config) NewDetectorRegistry() (*detection.Registry, error) {
+	registry := detection.NewRegistry()
+	for _, d := range c.Detectors {
+		detector, err := d.new()
+		if err != nil {
+			if d.Optional {
+				log.Printf("Skipping optional detector %s, the cameras using it run without it: %v", d.Name, err)
+				registry.Skip(d.Name)
+				continue
+			}
+			registry.Close()
+			return nil, fmt.Errorf("detector %s: %w", d.Name, err)
+		}
+		if err := registry.Register(d.Name, detector); err != nil {
+			detector.Close()
+			registry.Close()
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func (d DetectorConfig) new() (detection.Detector, error) {
+	switch d.Type {
+	case "cascade":
+		label := d.Label
+		if label == "" {
+			label = d.Name
+		}
+		return detection.NewCascadeDetector(d.Path, label)
+	case "hog":
+		return detection.NewPeopleDetector()
+	case "dnn":
+		cfg := detection.DNNConfig{Model: d.Model, Config: d.Config, Threshold: d.Threshold, Labels: d.Labels}
+		if d.InputSize != "" {
+			width, height, err := parseSize(d.InputSize)
+			if err != nil {
+				return nil, err
+			}
+			cfg.InputSize = image.Pt(int(width), int(height))
+		}
+		return detection.NewDNNDetector(cfg)
+	}
+	return nil, fmt.Errorf("unknown detector type %q", d.Type)
+}
+
+// NewNotificationActor creates the NotificationActor with the notifiers,
+// each following its own policy, and the alert rules of the rules file
+func (c *Config) NewNotificationActor() *actors.NotificationActor {
+	a := actors.NewNotificationActor().WithRuleStore(storage.NewRuleFile(c.RulesFile))
+	for _, n := range c.Notifiers {
+		switch n.Type {
+		case "email":
+			a.WithNotifier(n.Name, &notification.EmailNotifier{
+				SMTPServer: n.Email.SMTPServer,
+				Username:   n.Email.Username,
+				Password:   n.Email.Password,
+				From:       n.Email.From,
+				To:         n.Email.To,
+				UseTLS:     n.Email.UseTLS,
+			}, n.Policy())
+		case "sms":
+			a.WithNotifier(n.Name, &notification.SMSNotifier{
+				AccountSID: n.SMS.AccountSID,
+				AuthToken:  n.SMS.AuthToken,
+				From:       n.SMS.From,
+				To:         n.SMS.To,
+				BaseURL:    n.SMS.BaseURL,
+			}, n.Policy())
+		case "webhook":
+			webhook := &notification.WebhookNotifier{
+				URL:        n.Webhook.URL,
+				Secret:     n.Webhook.Secret,
+				Headers:    n.Webhook.Headers,
+				Image:      notification.WebhookImage(n.Webhook.Image),
+				Timeout:    n.Webhook.Timeout,
+				MaxRetries: n.Webhook.MaxRetries,
+			}
+			if webhook.Image == notification.WebhookImageURL {
+				webhook.ImageURL = clipURL(n.Webhook.ImageBaseURL)
+			}
+			a.WithNotifier(n.Name, webhook, n.Policy())
+		case "slack":
+			slack := &notification.SlackNotifier{
+				WebhookURL: n.Slack.WebhookURL,
+				Token:      n.Slack.Token,
+				Channel:    n.Slack.Channel,
+				BaseURL:    n.Slack.BaseURL,
+				MaxRetries: n.Slack.MaxRetries,
+			}
+			if n.Slack.ImageBaseURL != "" {
+				slack.ImageURL = clipURL(n.Slack.ImageBaseURL)
+			} else if n.Slack.WebhookURL != "" {
+				log.Printf("Slack notifier %s: incoming webhooks can't attach the snapshot, set image_base_url to link it", n.Name)
+			}
+			a.WithNotifier(n.Name, slack, n.Policy())
+		case "discord":
+			a.WithNotifier(n.Name, &notification.DiscordNotifier{
+				WebhookURL: n.Discord.WebhookURL,
+				Username:   n.Discord.Username,
+				MaxRetries: n.Discord.MaxRetries,
+			}, n.Policy())
+		case "telegram":
+			a.WithNotifier(n.Name, &notification.TelegramNotifier{
+				BotToken:   n.Telegram.BotToken,
+				ChatID:     n.Telegram.ChatID,
+				BaseURL:    n.Telegram.BaseURL,
+				MaxRetries: n.Telegram.MaxRetries,
+			}, n.Policy())
+		case "matrix":
+			a.WithNotifier(n.Name, &notification.MatrixNotifier{
+				Homeserver:  n.Matrix.Homeserver,
+				AccessToken: n.Matrix.AccessToken,
+				RoomID:      n.Matrix.RoomID,
+				MaxRetries:  n.Matrix.MaxRetries,
+			}, n.Policy())
+		}
+	}
+	return a
+}
+
+// clipURL links to the clip of an event served by the web UI at base. The
+// clip is stored by the StorageActor, it may lag behind the notification.
+func clipURL(base string) func(event *proto.DetectionEvent) string {
+	base = strings.TrimSuffix(base, "/")
+	return func(event *proto.DetectionEvent) string {
+		clip := &url.URL{Path: storage.ClipName(event.CameraId, time.UnixMilli(event.Timestamp))}
+		return base + "/clips/" + clip.EscapedPath()
+	}
+}
+
+// NewStorage opens the storage backend
+func (c *Config) NewStorage(ctx context.Context) (Backend, error) {
+	switch c.Storage.Backend {
+	case "filesystem":
+		return storage.NewFilesystemStorage(c.Storage.Filesystem.Dir)
+	case "gcs":
+		return storage.NewGCSStorage(ctx, c.Storage.GCS.Bucket)
+	}
+	return nil, fmt.Errorf("unknown storage backend %q", c.Storage.Backend)
+}
+
+// ClipsDir is the directory of the clips served by the web UI, empty when
+// they aren't stored locally
+func (c *Config) ClipsDir() string {
+	if c.Storage.Backend != "filesystem" {
+		return ""
+	}
+	return storage.ClipsDir(c.Storage.Filesystem.Dir)
+}
@@ -0,0 +1,591 @@
+// Package config loads the YAML file describing a SurveilSense deployment:
+// the HTTP server, the storage backend, the detectors, the notifiers and
+// the cameras started with it.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// Config is the root of the configuration file
+type Config struct {
+	HTTP        HTTPConfig       `yaml:"http"`
+	Processors  ProcessorsConfig `yaml:"processors"`
+	CamerasFile string           `yaml:"cameras_file"` // where the cameras edited at runtime are saved
+	RulesFile   string           `yaml:"rules_file"`   // alert rules routing the detections to the notifiers
+	Storage     StorageConfig    `yaml:"storage"`
+	Detectors   []DetectorConfig `yaml:"detectors"`
+	Notifiers   []NotifierConfig `yaml:"notifiers"`
+	Cameras     []CameraConfig   `yaml:"cameras"` // started unless already saved in CamerasFile
+}
+
+type HTTPConfig struct {
+	Listen string `yaml:"listen"`
+}
+
+type ProcessorsConfig struct {
+	Count   int    `yaml:"count"`
+	Routing string `yaml:"routing"`
+}
+
+// StorageConfig picks the backend storing the detections and clips
+type StorageConfig struct {
+	Backend    string                  `yaml:"backend"` // filesystem or gcs
+	Filesystem FilesystemStorageConfig `yaml:"filesystem"`
+	GCS        GCSStorageConfig        `yaml:"gcs"`
+}
+
+type FilesystemStorageConfig struct {
+	Dir string `yaml:"dir"` // holds detections.log and the clips directory
+}
+
+type GCSStorageConfig struct {
+	Bucket string `yaml:"bucket"`
+}
+
+// DetectorConfig registers a detector cameras can pick by name
+type DetectorConfig struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`     // cascade, hog or dnn
+	Optional bool   `yaml:"optional"` // skipped when it fails to load instead of failing the startup
+
+	// cascade
+	Path  string `yaml:"path"`
+	Label string `yaml:"label"`
+
+	// dnn
+	Model     string   `yaml:"model"`
+	Config    string   `yaml:"config"`
+	InputSize string   `yaml:"input_size"` // WIDTHxHEIGHT
+	Threshold float32  `yaml:"threshold"`
+	Labels    []string `yaml:"labels"`
+}
+
+// NotifierConfig describes a notifier of the NotificationActor. Only the
+// block matching its type is read.
+type NotifierConfig struct {
+	Name      string              `yaml:"name"`
+	Type      string              `yaml:"type"`      // email, sms, webhook, slack, discord, telegram or matrix
+	Cooldown  *time.Duration      `yaml:"cooldown"`  // between two notifications for a camera, default 1m, 0 notifies every event
+	Aggregate *bool               `yaml:"aggregate"` // sum up the events of the cooldown when it ends, default true
+	Email     EmailNotifierConfig `yaml:"email"`
+	SMS       SMSNotifierConfig   `yaml:"sms"`
+	Webhook   WebhookConfig       `yaml:"webhook"`
+	Slack     SlackConfig         `yaml:"slack"`
+	Discord   DiscordConfig       `yaml:"discord"`
+	Telegram  TelegramConfig      `yaml:"telegram"`
+	Matrix    MatrixConfig        `yaml:"matrix"`
+}
+
+// Policy returns the NotificationPolicy of the notifier
+func (n NotifierConfig) Policy() actors.NotificationPolicy {
+	policy := actors.DefaultNotificationPolicy
+	if n.Cooldown != nil {
+		policy.Cooldown = *n.Cooldown
+	}
+	if n.Aggregate != nil {
+		policy.Aggregate = *n.Aggregate
+	}
+	return policy
+}
+
+type EmailNotifierConfig struct {
+	SMTPServer string   `yaml:"smtp_server"` // host:port
+	Username   string   `yaml:"username"`
+	Password   string   `yaml:"password"`
+	From       string   `yaml:"from"`
+	To         []string `yaml:"to"`
+	UseTLS     bool     `yaml:"use_tls"`
+}
+
+type SMSNotifierConfig struct {
+	AccountSID string   `yaml:"account_sid"`
+	AuthToken  string   `yaml:"auth_token"`
+	From       string   `yaml:"from"`
+	To         []string `yaml:"to"`
+	BaseURL    string   `yaml:"base_url"` // Twilio compatible API, https://api.twilio.com by default
+}
+
+type WebhookConfig struct {
+	URL          string            `yaml:"url"`
+	Secret       string            `yaml:"secret"`         // signs the requests with HMAC-SHA256
+	Headers      map[string]string `yaml:"headers"`        // added to every request
+	Image        string            `yaml:"image"`          // none, base64 or url
+	ImageBaseURL string            `yaml:"image_base_url"` // address of the web UI serving the clips, with image: url
+	Timeout      time.Duration     `yaml:"timeout"`
+	MaxRetries   int               `yaml:"max_retries"`
+}
+
+// SlackConfig posts to an incoming webhook, or with a bot token to a
+// channel, uploading the snapshot
+type SlackConfig struct {
+	WebhookURL   string `yaml:"webhook_url"`
+	Token        string `yaml:"token"`
+	Channel      string `yaml:"channel"`        // channel ID, with token
+	BaseURL      string `yaml:"base_url"`       // Web API, https://slack.com/api by default
+	ImageBaseURL string `yaml:"image_base_url"` // address of the web UI serving the clips linked with webhook_url
+	MaxRetries   int    `yaml:"max_retries"`
+}
+
+type DiscordConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+	Username   string `yaml:"username"`
+	MaxRetries int    `yaml:"max_retries"`
+}
+
+type TelegramConfig struct {
+	BotToken   string `yaml:"bot_token"`
+	ChatID     string `yaml:"chat_id"`
+	BaseURL    string `yaml:"base_url"` // Bot API, https://api.telegram.org by default
+	MaxRetries int    `yaml:"max_retries"`
+}
+
+type MatrixConfig struct {
+	Homeserver  string `yaml:"homeserver"` // base URL of the client-server API
+	AccessToken string `yaml:"access_token"`
+	RoomID      string `yaml:"room_id"`
+	MaxRetries  int    `yaml:"max_retries"`
+}
+
+// CameraConfig describes a camera started with the system. Unset settings
+// take the defaults of the API.
+type CameraConfig struct {
+	ID          string   `yaml:"id"`
+	Source      string   `yaml:"source"`
+	FPS         float64  `yaml:"fps"`
+	Resolution  string   `yaml:"resolution"` // native or WIDTHxHEIGHT
+	KeepAspect  bool     `yaml:"keep_aspect"`
+	JPEGQuality int32    `yaml:"jpeg_quality"`
+	MaxInFlight int32    `yaml:"max_in_flight"`
+	DropPolicy  string   `yaml:"drop_policy"` // oldest or newest
+	Detectors   []string `yaml:"detectors"`
+	Motion      bool     `yaml:"motion"`
+	Tracking    *bool    `yaml:"tracking"` // on by default
+}
+
+// Default returns the configuration used without a file: the face, people
+// and, when its cascade is there, fullbody detectors, the filesystem storage
+// and no notifier
+func Default() *Config {
+	return &Config{
+		HTTP:        HTTPConfig{Listen: ":8080"},
+		Processors:  ProcessorsConfig{Count: runtime.NumCPU(), Routing: string(actors.CameraHashRouting)},
+		CamerasFile: "cameras.json",
+		RulesFile:   "rules.json",
+		Storage:     StorageConfig{Backend: "filesystem", Filesystem: FilesystemStorageConfig{Dir: "."}},
+		Detectors: []DetectorConfig{
+			{Name: actors.DefaultDetector, Type: "cascade", Path: "detection/haarcascade_frontalface_default.xml", Label: "face"},
+			{Name: "people", Type: "hog"},
+			// OpenCV's full body cascade isn't shipped, it is used when dropped next to the face one
+			{Name: "fullbody", Type: "cascade", Path: "detection/haarcascade_fullbody.xml", Label: "person", Optional: true},
+		},
+	}
+}
+
+// Load reads the configuration file at path over the defaults, then applies
+// the environment overrides. ${VAR} references in the file are replaced by
+// environment variables, to keep credentials out of it. The caller checks
+// the result with Validate, once it applied its own overrides.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		b, err = expandEnv(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		// the detectors of the file replace the default ones, if any
+		cfg.Detectors = nil
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if cfg.Detectors == nil {
+			cfg.Detectors = Default().Detectors
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the ${VAR} references of the values of the file, not
+// of its comments, failing on unset variables
+func expandEnv(b []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return b, nil
+	}
+	var missing []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode && envRef.MatchString(n.Value) {
+			n.Value = envRef.ReplaceAllStringFunc(n.Value, func(ref string) string {
+				name := envRef.FindStringSubmatch(ref)[1]
+				v, ok := os.LookupEnv(name)
+				if !ok {
+					missing = append(missing, name)
+				}
+				return v
+			})
+			// resolve the type of an unquoted value again, e.g. count: ${N}
+			// is an int, as it would be written in the file
+			if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				n.Tag = ""
+			}
+		}
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(&doc)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return yaml.Marshal(&doc)
+}
+
+// applyEnv overrides the settings with the SURVEILSENSE_* environment variables
+func (c *Config) applyEnv() error {
+	overrides := map[string]*string{
+		"SURVEILSENSE_HTTP_LISTEN":     &c.HTTP.Listen,
+		"SURVEILSENSE_ROUTING":         &c.Processors.Routing,
+		"SURVEILSENSE_CAMERAS_FILE":    &c.CamerasFile,
+		"SURVEILSENSE_RULES_FILE":      &c.RulesFile,
+		"SURVEILSENSE_STORAGE_BACKEND": &c.Storage.Backend,
+		"SURVEILSENSE_STORAGE_DIR":     &c.Storage.Filesystem.Dir,
+		"SURVEILSENSE_GCS_BUCKET":      &c.Storage.GCS.Bucket,
+	}
+	for name, field := range overrides {
+		if v, ok := os.LookupEnv(name); ok {
+			*field = v
+		}
+	}
+	if v, ok := os.LookupEnv("SURVEILSENSE_PROCESSORS"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("SURVEILSENSE_PROCESSORS: invalid number %q", v)
+		}
+		c.Processors.Count = n
+	}
+	return nil
+}
+
+// Validate checks the whole configuration and reports every problem found,
+// each prefixed with the path of the setting
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+	// checkURL fails on an invalid URL, or a missing one when required
+	checkURL := func(path, s string, required bool) {
+		if s == "" {
+			if required {
+				fail(path, "required")
+			}
+		} else if err := validateURL(s); err != nil {
+			fail(path, "%v", err)
+		}
+	}
+
+	if c.HTTP.Listen == "" {
+		fail("http.listen", "required")
+	}
+	if c.Processors.Count < 1 {
+		fail("processors.count", "must be at least 1, got %d", c.Processors.Count)
+	}
+	if _, err := actors.ParseRoutingStrategy(c.Processors.Routing); err != nil {
+		fail("processors.routing", "%v", err)
+	}
+	if c.CamerasFile == "" {
+		fail("cameras_file", "required")
+	}
+	if c.RulesFile == "" {
+		fail("rules_file", "required")
+	}
+
+	switch c.Storage.Backend {
+	case "filesystem":
+		if c.Storage.Filesystem.Dir == "" {
+			fail("storage.filesystem.dir", "required")
+		}
+	case "gcs":
+		if c.Storage.GCS.Bucket == "" {
+			fail("storage.gcs.bucket", "required")
+		}
+	default:
+		fail("storage.backend", "unknown backend %q, expected filesystem or gcs", c.Storage.Backend)
+	}
+
+	detectors := make(map[string]bool)
+	for i, d := range c.Detectors {
+		path := fmt.Sprintf("detectors[%d]", i)
+		if d.Name == "" {
+			fail(path+".name", "required")
+		} else if detectors[d.Name] {
+			fail(path+".name", "duplicate detector %q", d.Name)
+		}
+		detectors[d.Name] = true
+		switch d.Type {
+		case "cascade":
+			if d.Path == "" {
+				fail(path+".path", "required for a cascade detector")
+			}
+		case "hog":
+		case "dnn":
+			if d.Model == "" {
+				fail(path+".model", "required for a dnn detector")
+			}
+			if d.InputSize != "" {
+				if _, _, err := parseSize(d.InputSize); err != nil {
+					fail(path+".input_size", "%v", err)
+				}
+			}
+			if !finite(float64(d.Threshold)) || d.Threshold < 0 || d.Threshold > 1 {
+				fail(path+".threshold", "must be in [0, 1], got %g", d.Threshold)
+			}
+		default:
+			fail(path+".type", "unknown detector type %q, expected cascade, hog or dnn", d.Type)
+		}
+	}
+
+	notifiers := make(map[string]bool)
+	for i, n := range c.Notifiers {
+		path := fmt.Sprintf("notifiers[%d]", i)
+		if n.Name == "" {
+			fail(path+".name", "required")
+		} else if notifiers[n.Name] {
+			fail(path+".name", "duplicate notifier %q", n.Name)
+		}
+		notifiers[n.Name] = true
+		if n.Cooldown != nil && *n.Cooldown < 0 {
+			fail(path+".cooldown", "must not be negative, got %s", *n.Cooldown)
+		}
+		switch n.Type {
+		case "email":
+			if n.Email.SMTPServer == "" {
+				fail(path+".email.smtp_server", "required")
+			} else if !strings.Contains(n.Email.SMTPServer, ":") {
+				fail(path+".email.smtp_server", "expected host:port, got %q", n.Email.SMTPServer)
+			}
+			if n.Email.From == "" {
+				fail(path+".email.from", "required")
+			}
+			if len(n.Email.To) == 0 {
+				fail(path+".email.to", "at least one recipient is required")
+			}
+		case "sms":
+			if n.SMS.AccountSID == "" {
+				fail(path+".sms.account_sid", "required")
+			}
+			if n.SMS.AuthToken == "" {
+				fail(path+".sms.auth_token", "required")
+			}
+			if n.SMS.From == "" {
+				fail(path+".sms.from", "required")
+			}
+			if len(n.SMS.To) == 0 {
+				fail(path+".sms.to", "at least one recipient is required")
+			}
+			if n.SMS.BaseURL != "" {
+				if err := validateURL(n.SMS.BaseURL); err != nil {
+					fail(path+".sms.base_url", "%v", err)
+				}
+			}
+		case "webhook":
+			if n.Webhook.URL == "" {
+				fail(path+".webhook.url", "required")
+			} else if err := validateURL(n.Webhook.URL); err != nil {
+				fail(path+".webhook.url", "%v", err)
+			}
+			switch n.Webhook.Image {
+			case "", "none", "base64":
+			case "url":
+				if c.Storage.Backend != "filesystem" {
+					fail(path+".webhook.image", "url needs the filesystem storage, the web UI serves its clips")
+				}
+				if n.Webhook.ImageBaseURL == "" {
+					fail(path+".webhook.image_base_url", "required with image: url")
+				} else if err := validateURL(n.Webhook.ImageBaseURL); err != nil {
+					fail(path+".webhook.image_base_url", "%v", err)
+				}
+			default:
+				fail(path+".webhook.image", "unknown image mode %q, expected none, base64 or url", n.Webhook.Image)
+			}
+			if n.Webhook.Timeout < 0 {
+				fail(path+".webhook.timeout", "must not be negative, got %s", n.Webhook.Timeout)
+			}
+		case "slack":
+			switch {
+			case n.Slack.Token != "" && n.Slack.WebhookURL != "":
+				fail(path+".slack", "webhook_url and token are exclusive, set one of them")
+			case n.Slack.Token != "":
+				if n.Slack.Channel == "" {
+					fail(path+".slack.channel", "required with token")
+				}
+				checkURL(path+".slack.base_url", n.Slack.BaseURL, false)
+			case n.Slack.WebhookURL != "":
+				checkURL(path+".slack.webhook_url", n.Slack.WebhookURL, true)
+				if n.Slack.ImageBaseURL != "" && c.Storage.Backend != "filesystem" {
+					fail(path+".slack.image_base_url", "needs the filesystem storage, the web UI serves its clips")
+				}
+				checkURL(path+".slack.image_base_url", n.Slack.ImageBaseURL, false)
+			default:
+				fail(path+".slack", "webhook_url or token is required")
+			}
+		case "discord":
+			checkURL(path+".discord.webhook_url", n.Discord.WebhookURL, true)
+		case "telegram":
+			if n.Telegram.BotToken == "" {
+				fail(path+".telegram.bot_token", "required")
+			}
+			if n.Telegram.ChatID == "" {
+				fail(path+".telegram.chat_id", "required")
+			}
+			checkURL(path+".telegram.base_url", n.Telegram.BaseURL, false)
+		case "matrix":
+			checkURL(path+".matrix.homeserver", n.Matrix.Homeserver, true)
+			if n.Matrix.AccessToken == "" {
+				fail(path+".matrix.access_token", "required")
+			}
+			if n.Matrix.RoomID == "" {
+				fail(path+".matrix.room_id", "required")
+			}
+		default:
+			fail(path+".type", "unknown notifier type %q, expected email, sms, webhook, slack, discord, telegram or matrix", n.Type)
+		}
+	}
+
+	cameras := make(map[string]bool)
+	for i, cam := range c.Cameras {
+		path := fmt.Sprintf("cameras[%d]", i)
+		if cam.ID != "" {
+			path = fmt.Sprintf("cameras[%s]", cam.ID)
+		}
+		if cameras[cam.ID] {
+			fail(path+".id", "duplicate camera")
+		}
+		cameras[cam.ID] = true
+		for _, name := range cam.Detectors {
+			if !detectors[name] {
+				fail(path+".detectors", "unknown detector %q", name)
+			}
+		}
+		if _, err := cam.Spec(); err != nil {
+			fail(path, "%v", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Spec turns the camera into the spec added to the SystemCoordinatorActor
+func (c CameraConfig) Spec() (*proto.CameraSpec, error) {
+	capture := actors.DefaultCaptureConfig()
+	if c.FPS != 0 {
+		capture.Fps = c.FPS
+	}
+	switch c.Resolution {
+	case "":
+	case "native":
+		capture.Width, capture.Height = 0, 0
+	default:
+		width, height, err := parseSize(c.Resolution)
+		if err != nil {
+			return nil, fmt.Errorf("resolution: %w", err)
+		}
+		capture.Width, capture.Height = width, height
+	}
+	capture.KeepAspectRatio = c.KeepAspect
+	if c.JPEGQuality != 0 {
+		capture.JpegQuality = c.JPEGQuality
+	}
+	if c.MaxInFlight != 0 {
+		capture.MaxInFlight = c.MaxInFlight
+	}
+	switch c.DropPolicy {
+	case "", "oldest":
+	case "newest":
+		capture.DropPolicy = proto.FrameDropPolicy_FRAME_DROP_POLICY_DROP_NEWEST
+	default:
+		return nil, fmt.Errorf("drop_policy: expected oldest or newest, got %q", c.DropPolicy)
+	}
+
+	processing := actors.DefaultProcessingConfig()
+	if len(c.Detectors) > 0 {
+		processing.Detectors = c.Detectors
+	}
+	processing.Motion.Enabled = c.Motion
+	if c.Tracking != nil {
+		processing.Tracking.Enabled = *c.Tracking
+	}
+
+	spec := &proto.CameraSpec{CameraId: c.ID, Source: c.Source, Capture: capture, Processing: processing}
+	if spec.Source == "" {
+		return nil, fmt.Errorf("source: required")
+	}
+	if err := actors.ValidateCameraSpec(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// validateURL checks that s is an absolute http or https URL. The errors
+// leave s out, webhook URLs carry a token.
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		// the url.Error repeats s
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("invalid URL: %w", urlErr.Err)
+		}
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("expected an absolute http or https URL")
+	}
+	return nil
+}
+
+// finite tells whether f is a number that can be range checked, NaN fails
+// every comparison
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// parseSize parses WIDTHxHEIGHT
+func parseSize(s string) (int32, int32, error) {
+	var width, height int32
+	if _, err := fmt.Sscanf(s, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid size %q, expected WIDTHxHEIGHT", s)
+	}
+	return width, height, nil
+}
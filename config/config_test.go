@@ -0,0 +1,267 @@
+package config
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+	negative := -time.Second
+	tests := []struct {
+		name    string
+		edit    func(c *Config)
+		wantErr string // in the error, empty for a valid configuration
+	}{
+		{name: "defaults", edit: func(c *Config) {}},
+		{name: "no listen address", edit: func(c *Config) { c.HTTP.Listen = "" }, wantErr: "http.listen: required"},
+		{name: "no processor", edit: func(c *Config) { c.Processors.Count = 0 }, wantErr: "processors.count"},
+		{name: "unknown routing", edit: func(c *Config) { c.Processors.Routing = "random" }, wantErr: "processors.routing"},
+		{name: "unknown storage", edit: func(c *Config) { c.Storage.Backend = "s3" }, wantErr: "storage.backend"},
+		{name: "gcs without bucket", edit: func(c *Config) { c.Storage.Backend = "gcs" }, wantErr: "storage.gcs.bucket: required"},
+		{
+			name:    "duplicate detector",
+			edit:    func(c *Config) { c.Detectors = append(c.Detectors, DetectorConfig{Name: "people", Type: "hog"}) },
+			wantErr: `detectors[3].name: duplicate detector "people"`,
+		},
+		{
+			name: "dnn threshold out of range",
+			edit: func(c *Config) {
+				c.Detectors = append(c.Detectors, DetectorConfig{Name: "objects", Type: "dnn", Model: "ssd.onnx", Threshold: 1.5})
+			},
+			wantErr: "detectors[3].threshold",
+		},
+		{
+			name: "dnn threshold NaN",
+			edit: func(c *Config) {
+				c.Detectors = append(c.Detectors, DetectorConfig{Name: "objects", Type: "dnn", Model: "ssd.onnx", Threshold: float32(math.NaN())})
+			},
+			wantErr: "detectors[3].threshold",
+		},
+		{
+			name: "invalid webhook url",
+			edit: func(c *Config) {
+				c.Notifiers = []NotifierConfig{{Name: "discord", Type: "discord", Discord: DiscordConfig{WebhookURL: "discord.com/api/webhooks/1/s3cret"}}}
+			},
+			wantErr: "notifiers[0].discord.webhook_url: expected an absolute http or https URL",
+		},
+		{
+			name: "unparsable webhook url",
+			edit: func(c *Config) {
+				c.Notifiers = []NotifierConfig{{Name: "hook", Type: "webhook", Webhook: WebhookConfig{URL: "https://example.com:port/s3cret"}}}
+			},
+			wantErr: "notifiers[0].webhook.url: invalid URL",
+		},
+		{
+			name:    "unknown notifier type",
+			edit:    func(c *Config) { c.Notifiers = []NotifierConfig{{Name: "pager", Type: "pager"}} },
+			wantErr: "notifiers[0].type",
+		},
+		{
+			name: "negative cooldown",
+			edit: func(c *Config) {
+				c.Notifiers = []NotifierConfig{{Name: "hook", Type: "webhook", Cooldown: &negative, Webhook: WebhookConfig{URL: "https://example.com"}}}
+			},
+			wantErr: "notifiers[0].cooldown",
+		},
+		{
+			name: "webhook image url without the filesystem storage",
+			edit: func(c *Config) {
+				c.Storage = StorageConfig{Backend: "gcs", GCS: GCSStorageConfig{Bucket: "clips"}}
+				c.Notifiers = []NotifierConfig{{Name: "hook", Type: "webhook", Webhook: WebhookConfig{
+					URL: "https://example.com", Image: "url", ImageBaseURL: "https://surveilsense.example.com",
+				}}}
+			},
+			wantErr: "notifiers[0].webhook.image",
+		},
+		{
+			name:    "slack without webhook or token",
+			edit:    func(c *Config) { c.Notifiers = []NotifierConfig{{Name: "slack", Type: "slack"}} },
+			wantErr: "notifiers[0].slack: webhook_url or token is required",
+		},
+		{
+			name: "slack with both webhook and token",
+			edit: func(c *Config) {
+				c.Notifiers = []NotifierConfig{{Name: "slack", Type: "slack", Slack: SlackConfig{
+					WebhookURL: "https://hooks.slack.com/services/T0/B0/x", Token: "xoxb-1", Channel: "C0123456789",
+				}}}
+			},
+			wantErr: "notifiers[0].slack: webhook_url and token are exclusive",
+		},
+		{
+			name: "camera with an unknown detector",
+			edit: func(c *Config) {
+				c.Cameras = []CameraConfig{{ID: "door", Source: "test://", Detectors: []string{"cars"}}}
+			},
+			wantErr: `cameras[door].detectors: unknown detector "cars"`,
+		},
+		{
+			name:    "camera without source",
+			edit:    func(c *Config) { c.Cameras = []CameraConfig{{ID: "door"}} },
+			wantErr: "cameras[door]: source: required",
+		},
+		{
+			name: "duplicate camera",
+			edit: func(c *Config) {
+				c.Cameras = []CameraConfig{{ID: "door", Source: "test://"}, {ID: "door", Source: "test://"}}
+			},
+			wantErr: "cameras[door].id: duplicate camera",
+		},
+		{
+			name: "every problem is reported",
+			edit: func(c *Config) {
+				c.HTTP.Listen = ""
+				c.CamerasFile = ""
+			},
+			wantErr: "http.listen: required\ncameras_file: required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.edit(c)
+			err := c.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want an error with %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("SMTP_PASSWORD", "s3cret")
+	t.Setenv("SMTP_HOST", "mail.example.com")
+	t.Setenv("EMPTY", "")
+	t.Setenv("COUNT", "4")
+	t.Setenv("TLS", "true")
+	tests := []struct {
+		name    string
+		file    string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "no reference",
+			file: "listen: :8080",
+			want: map[string]any{"listen": ":8080"},
+		},
+		{
+			name: "whole value",
+			file: "password: ${SMTP_PASSWORD}",
+			want: map[string]any{"password": "s3cret"},
+		},
+		{
+			name: "several references in a value",
+			file: "server: ${SMTP_HOST}:587/${SMTP_PASSWORD}",
+			want: map[string]any{"server": "mail.example.com:587/s3cret"},
+		},
+		{
+			name: "in a list",
+			file: "to: [a@example.com, '${SMTP_HOST}']",
+			want: map[string]any{"to": []any{"a@example.com", "mail.example.com"}},
+		},
+		{
+			name: "unquoted values are typed",
+			file: "count: ${COUNT}\nuse_tls: ${TLS}\nfps: ${COUNT}.5",
+			want: map[string]any{"count": 4, "use_tls": true, "fps": 4.5},
+		},
+		{
+			name: "quoted values are strings",
+			file: "password: '${COUNT}'\nenabled: \"${TLS}\"",
+			want: map[string]any{"password": "4", "enabled": "true"},
+		},
+		{
+			name: "set to empty",
+			file: "password: x${EMPTY}",
+			want: map[string]any{"password": "x"},
+		},
+		{
+			name: "comments are left alone",
+			file: "# password: ${NOT_SET}\npassword: ${SMTP_PASSWORD} # or ${NOT_SET_EITHER}",
+			want: map[string]any{"password": "s3cret"},
+		},
+		{
+			name: "$VAR without braces is kept",
+			file: "password: $SMTP_PASSWORD",
+			want: map[string]any{"password": "$SMTP_PASSWORD"},
+		},
+		{
+			name:    "unset variables",
+			file:    "user: ${NOT_SET}\npassword: ${NOT_SET_EITHER}",
+			wantErr: "environment variables not set: NOT_SET, NOT_SET_EITHER",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := expandEnv([]byte(tt.file))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expandEnv() = %v, want error %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandEnv() = %v", err)
+			}
+			var got map[string]any
+			if err := yaml.Unmarshal(b, &got); err != nil {
+				t.Fatalf("expanded file: %v", err)
+			}
+			if g, w := yamlString(t, got), yamlString(t, tt.want); g != w {
+				t.Errorf("expanded to\n%s\nwant\n%s", g, w)
+			}
+		})
+	}
+}
+
+func TestLoadExpandsTypedValues(t *testing.T) {
+	t.Setenv("PROCESSORS", "8")
+	t.Setenv("SMTP_TLS", "true")
+	t.Setenv("RETRIES", "5")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	file := `
+processors:
+  count: ${PROCESSORS}
+notifiers:
+  - name: mail
+    type: email
+    email:
+      use_tls: ${SMTP_TLS}
+  - name: hook
+    type: webhook
+    webhook:
+      url: https://example.com
+      max_retries: ${RETRIES}
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if cfg.Processors.Count != 8 || !cfg.Notifiers[0].Email.UseTLS || cfg.Notifiers[1].Webhook.MaxRetries != 5 {
+		t.Errorf("loaded count %d, use_tls %v and max_retries %d, want 8, true and 5",
+			cfg.Processors.Count, cfg.Notifiers[0].Email.UseTLS, cfg.Notifiers[1].Webhook.MaxRetries)
+	}
+}
+
+func yamlString(t *testing.T, v any) string {
+	t.Helper()
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -2,32 +2,199 @@ package detection
 
 import (
 	_ "embed"
+	"fmt"
 	"image"
+	"math"
+	"sync"
 
 	"gocv.io/x/gocv"
 )
 
+// Result is an object found by a Detector
+type Result struct {
+	Label      string          // class of the object, e.g. face or person
+	Confidence float32         // score in [0, 1]
+	Box        image.Rectangle // location in the frame
+}
+
 type Detector interface {
-	Detect(img gocv.Mat) []image.Rectangle
+	Detect(img gocv.Mat) []Result
 	Close()
 }
 
+// Forker is implemented by the detectors that can create independent
+// instances of themselves. OpenCV models aren't safe for concurrent use,
+// each frame processor works on its own fork.
+type Forker interface {
+	// Fork loads a new instance sharing the settings of the detector
+	Fork() (Detector, error)
+}
+
+const groupEps = 0.2
+
 func NewFaceDetector(data string) Detector {
-	cascade := gocv.NewCascadeClassifier()
-	if !cascade.Load(data) {
+	d, err := NewCascadeDetector(data, "face")
+	if err != nil {
 		panic("failed to load face detection model")
 	}
-	return &faceDetector{classifier: cascade}
+	return d
+}
+
+// CascadeParams tunes the detection of a CascadeDetector
+type CascadeParams struct {
+	ScaleFactor  float64 `json:"scale_factor"`  // image pyramid step, > 1
+	MinNeighbors int     `json:"min_neighbors"` // candidates needed to keep a detection
+	MinSize      Size    `json:"min_size"`      // smallest object size, 0x0 for no limit
+	MaxSize      Size    `json:"max_size"`      // largest object size, 0x0 for no limit
+	Grayscale    bool    `json:"grayscale"`     // convert frames to grayscale first
+	EqualizeHist bool    `json:"equalize_hist"` // equalize the histogram of the grayscale frame
+}
+
+// Size is an object size, written WIDTHxHEIGHT as in the forms
+type Size image.Point
+
+// ParseSize parses WIDTHxHEIGHT
+func ParseSize(s string) (Size, error) {
+	var size Size
+	if _, err := fmt.Sscanf(s, "%dx%d", &size.X, &size.Y); err != nil {
+		return Size{}, fmt.Errorf("invalid size %q, expected WIDTHxHEIGHT", s)
+	}
+	return size, nil
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.X, s.Y)
+}
+
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Size) UnmarshalText(b []byte) error {
+	size, err := ParseSize(string(b))
+	if err != nil {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+// DefaultCascadeParams returns OpenCV's defaults
+func DefaultCascadeParams() CascadeParams {
+	return CascadeParams{
+		ScaleFactor:  1.1,
+		MinNeighbors: 3,
+	}
+}
+
+// Validate checks that the parameters can be used for detection
+func (p CascadeParams) Validate() error {
+	// NaN fails every comparison
+	if math.IsNaN(p.ScaleFactor) || math.IsInf(p.ScaleFactor, 0) || p.ScaleFactor <= 1 || p.ScaleFactor > 2 {
+		return fmt.Errorf("scale factor must be in (1, 2], got %g", p.ScaleFactor)
+	}
+	if p.MinNeighbors < 0 {
+		return fmt.Errorf("min neighbors must not be negative, got %d", p.MinNeighbors)
+	}
+	if p.MinSize.X < 0 || p.MinSize.Y < 0 || p.MaxSize.X < 0 || p.MaxSize.Y < 0 {
+		return fmt.Errorf("object sizes must not be negative")
+	}
+	if p.MaxSize != (Size{}) && (p.MaxSize.X < p.MinSize.X || p.MaxSize.Y < p.MinSize.Y) {
+		return fmt.Errorf("max size %s is smaller than min size %s", p.MaxSize, p.MinSize)
+	}
+	if p.EqualizeHist && !p.Grayscale {
+		return fmt.Errorf("histogram equalization requires grayscale")
+	}
+	return nil
 }
 
-type faceDetector struct {
+// NewCascadeDetector creates a Detector from a Haar or LBP cascade file,
+// e.g. haarcascade_frontalface_default.xml or haarcascade_fullbody.xml.
+// Results are tagged with label.
+func NewCascadeDetector(path, label string) (*CascadeDetector, error) {
+	return NewCascadeDetectorWithParams(path, label, DefaultCascadeParams())
+}
+
+// NewCascadeDetectorWithParams creates a CascadeDetector tuned with params
+func NewCascadeDetectorWithParams(path, label string, params CascadeParams) (*CascadeDetector, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	cascade := gocv.NewCascadeClassifier()
+	if !cascade.Load(path) {
+		cascade.Close()
+		return nil, fmt.Errorf("failed to load cascade %s", path)
+	}
+	return &CascadeDetector{classifier: cascade, path: path, label: label, settings: &cascadeSettings{params: params}}, nil
+}
+
+// CascadeDetector runs a Haar or LBP cascade. Its parameters can be changed
+// while it is in use, and are shared with its forks.
+type CascadeDetector struct {
 	classifier gocv.CascadeClassifier
+	path       string
+	label      string
+	settings   *cascadeSettings
+}
+
+type cascadeSettings struct {
+	mu     sync.RWMutex
+	params CascadeParams
+}
+
+// Params returns the parameters currently used
+func (d *CascadeDetector) Params() CascadeParams {
+	d.settings.mu.RLock()
+	defer d.settings.mu.RUnlock()
+	return d.settings.params
+}
+
+// SetParams validates and applies new parameters, used from the next Detect call on
+func (d *CascadeDetector) SetParams(params CascadeParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	d.settings.mu.Lock()
+	defer d.settings.mu.Unlock()
+	d.settings.params = params
+	return nil
 }
 
-func (d *faceDetector) Detect(img gocv.Mat) []image.Rectangle {
-	return d.classifier.DetectMultiScale(img)
+// Fork loads the cascade again, the fork follows the parameters set on d
+func (d *CascadeDetector) Fork() (Detector, error) {
+	cascade := gocv.NewCascadeClassifier()
+	if !cascade.Load(d.path) {
+		cascade.Close()
+		return nil, fmt.Errorf("failed to load cascade %s", d.path)
+	}
+	return &CascadeDetector{classifier: cascade, path: d.path, label: d.label, settings: d.settings}, nil
+}
+
+func (d *CascadeDetector) Detect(img gocv.Mat) []Result {
+	params := d.Params()
+
+	if params.Grayscale {
+		gray := gocv.NewMat()
+		defer gray.Close()
+		gocv.CvtColor(img, &gray, gocv.ColorBGRToGray)
+		if params.EqualizeHist {
+			gocv.EqualizeHist(gray, &gray)
+		}
+		img = gray
+	}
+
+	// gocv doesn't expose the level weights of detectMultiScale3, so the raw
+	// candidates are grouped here to score each detection by its neighbours
+	candidates := d.classifier.DetectMultiScaleWithParams(img, params.ScaleFactor, 0, 0, image.Point(params.MinSize), image.Point(params.MaxSize))
+	groups := groupCandidates(candidates, params.MinNeighbors, groupEps)
+
+	results := make([]Result, 0, len(groups))
+	for _, g := range groups {
+		results = append(results, Result{Label: d.label, Confidence: g.confidence(), Box: g.box})
+	}
+	return results
 }
 
-func (d *faceDetector) Close() {
+func (d *CascadeDetector) Close() {
 	d.classifier.Close()
 }
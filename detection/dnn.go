@@ -0,0 +1,108 @@
+package detection
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+
+	"gocv.io/x/gocv"
+)
+
+// DNNConfig describes an SSD style object detection network, such as the
+// Caffe res10 face detector or a MobileNet-SSD exported to ONNX.
+// The network output must have the shape 1x1xNx7 where each detection is
+// [batchId, classId, confidence, left, top, right, bottom] in relative coordinates.
+type DNNConfig struct {
+	Model     string      // .caffemodel, .onnx, ... weights
+	Config    string      // .prototxt for Caffe models, empty for ONNX
+	InputSize image.Point // network input size, defaults to 300x300
+	Threshold float32     // minimum confidence, defaults to 0.5
+	Labels    []string    // class names by class id, e.g. the VOC classes of MobileNet-SSD
+
+	// Preprocessing, defaults depend on the model format
+	Scale  float64
+	Mean   gocv.Scalar
+	SwapRB bool
+}
+
+// NewDNNDetector loads the network described by cfg
+func NewDNNDetector(cfg DNNConfig) (Detector, error) {
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("missing DNN model file")
+	}
+	if cfg.InputSize == (image.Point{}) {
+		cfg.InputSize = image.Pt(300, 300)
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = 0.5
+	}
+	if cfg.Scale == 0 {
+		if filepath.Ext(cfg.Model) == ".caffemodel" {
+			cfg.Scale = 1.0
+			cfg.Mean = gocv.NewScalar(104, 177, 123, 0)
+			cfg.SwapRB = false
+		} else {
+			cfg.Scale = 1.0 / 127.5
+			cfg.Mean = gocv.NewScalar(127.5, 127.5, 127.5, 0)
+			cfg.SwapRB = true
+		}
+	}
+
+	net := gocv.ReadNet(cfg.Model, cfg.Config)
+	if net.Empty() {
+		return nil, fmt.Errorf("failed to read network %s", cfg.Model)
+	}
+	return &dnnDetector{net: net, cfg: cfg}, nil
+}
+
+type dnnDetector struct {
+	net gocv.Net
+	cfg DNNConfig
+}
+
+func (d *dnnDetector) Detect(img gocv.Mat) []Result {
+	blob := gocv.BlobFromImage(img, d.cfg.Scale, d.cfg.InputSize, d.cfg.Mean, d.cfg.SwapRB, false)
+	defer blob.Close()
+	d.net.SetInput(blob, "")
+	results := d.net.Forward("")
+	defer results.Close()
+
+	bounds := image.Rect(0, 0, img.Cols(), img.Rows())
+	var detections []Result
+	for i := 0; i+6 < results.Total(); i += 7 {
+		confidence := results.GetFloatAt(0, i+2)
+		if confidence < d.cfg.Threshold {
+			continue
+		}
+		rect := image.Rect(
+			int(results.GetFloatAt(0, i+3)*float32(img.Cols())),
+			int(results.GetFloatAt(0, i+4)*float32(img.Rows())),
+			int(results.GetFloatAt(0, i+5)*float32(img.Cols())),
+			int(results.GetFloatAt(0, i+6)*float32(img.Rows())),
+		).Intersect(bounds)
+		if !rect.Empty() {
+			detections = append(detections, Result{
+				Label:      d.label(int(results.GetFloatAt(0, i+1))),
+				Confidence: confidence,
+				Box:        rect,
+			})
+		}
+	}
+	return detections
+}
+
+// label returns the class name of a class id, the id itself when unknown
+func (d *dnnDetector) label(classID int) string {
+	if classID >= 0 && classID < len(d.cfg.Labels) {
+		return d.cfg.Labels[classID]
+	}
+	return fmt.Sprintf("class-%d", classID)
+}
+
+func (d *dnnDetector) Fork() (Detector, error) {
+	return NewDNNDetector(d.cfg)
+}
+
+func (d *dnnDetector) Close() {
+	d.net.Close()
+}
@@ -0,0 +1,112 @@
+package detection
+
+import (
+	"image"
+	"math"
+)
+
+// confidenceScale controls how fast the neighbour based confidence grows:
+// a group backed by confidenceScale candidates scores 0.5
+const confidenceScale = 6
+
+// candidateGroup is a cluster of raw candidate boxes
+type candidateGroup struct {
+	box       image.Rectangle // average of the candidates
+	neighbors int             // number of candidates in the cluster
+}
+
+// confidence turns the number of candidates backing a detection into a score in [0, 1).
+// Cascades and HOG don't expose a calibrated score through gocv, but a detection
+// found at many scales and offsets is far more reliable than one found once.
+func (g candidateGroup) confidence() float32 {
+	return float32(g.neighbors) / float32(g.neighbors+confidenceScale)
+}
+
+// groupCandidates clusters similar boxes like OpenCV's groupRectangles does,
+// keeping the clusters of more than minNeighbors boxes along with their size
+func groupCandidates(rects []image.Rectangle, minNeighbors int, eps float64) []candidateGroup {
+	// union-find over the similarity relation
+	parent := make([]int, len(rects))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if similarRects(rects[i], rects[j], eps) {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	type sum struct{ x0, y0, x1, y1, n int }
+	sums := make(map[int]*sum)
+	var order []int
+	for i, r := range rects {
+		root := find(i)
+		s, ok := sums[root]
+		if !ok {
+			s = &sum{}
+			sums[root] = s
+			order = append(order, root)
+		}
+		s.x0 += r.Min.X
+		s.y0 += r.Min.Y
+		s.x1 += r.Max.X
+		s.y1 += r.Max.Y
+		s.n++
+	}
+
+	groups := make([]candidateGroup, 0, len(order))
+	for _, root := range order {
+		s := sums[root]
+		if s.n <= minNeighbors {
+			continue
+		}
+		groups = append(groups, candidateGroup{
+			box:       image.Rect(s.x0/s.n, s.y0/s.n, s.x1/s.n, s.y1/s.n),
+			neighbors: s.n,
+		})
+	}
+	return dropNested(groups, eps)
+}
+
+// similarRects is OpenCV's SimilarRects predicate
+func similarRects(a, b image.Rectangle, eps float64) bool {
+	delta := eps * float64(min(a.Dx(), b.Dx())+min(a.Dy(), b.Dy())) * 0.5
+	return math.Abs(float64(a.Min.X-b.Min.X)) <= delta &&
+		math.Abs(float64(a.Min.Y-b.Min.Y)) <= delta &&
+		math.Abs(float64(a.Max.X-b.Max.X)) <= delta &&
+		math.Abs(float64(a.Max.Y-b.Max.Y)) <= delta
+}
+
+// dropNested removes the groups lying inside a better supported group, as OpenCV does
+func dropNested(groups []candidateGroup, eps float64) []candidateGroup {
+	kept := make([]candidateGroup, 0, len(groups))
+	for i, g := range groups {
+		nested := false
+		for j, other := range groups {
+			if i == j {
+				continue
+			}
+			dx := int(float64(other.box.Dx()) * eps)
+			dy := int(float64(other.box.Dy()) * eps)
+			inside := g.box.Min.X >= other.box.Min.X-dx && g.box.Min.Y >= other.box.Min.Y-dy &&
+				g.box.Max.X <= other.box.Max.X+dx && g.box.Max.Y <= other.box.Max.Y+dy
+			if inside && (other.neighbors > max(3, g.neighbors) || g.neighbors < 3) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,51 @@
+package detection
+
+import (
+	"image"
+
+	"gocv.io/x/gocv"
+)
+
+// OpenCV's defaults for HOGDescriptor::detectMultiScale
+const (
+	hogScale          = 1.05
+	hogFinalThreshold = 2
+)
+
+// NewPeopleDetector creates a Detector finding standing people with
+// OpenCV's HOG descriptor and its default people SVM
+func NewPeopleDetector() (Detector, error) {
+	hog := gocv.NewHOGDescriptor()
+	svm := gocv.HOGDefaultPeopleDetector()
+	defer svm.Close()
+	if err := hog.SetSVMDetector(svm); err != nil {
+		hog.Close()
+		return nil, err
+	}
+	return &hogDetector{hog: hog}, nil
+}
+
+type hogDetector struct {
+	hog gocv.HOGDescriptor
+}
+
+func (d *hogDetector) Detect(img gocv.Mat) []Result {
+	// a final threshold of 0 disables OpenCV's grouping, so the candidates
+	// backing each detection can be counted to score it
+	candidates := d.hog.DetectMultiScaleWithParams(img, 0, image.Pt(8, 8), image.Point{}, hogScale, 0, false)
+	groups := groupCandidates(candidates, hogFinalThreshold, groupEps)
+
+	results := make([]Result, 0, len(groups))
+	for _, g := range groups {
+		results = append(results, Result{Label: "person", Confidence: g.confidence(), Box: g.box})
+	}
+	return results
+}
+
+func (d *hogDetector) Fork() (Detector, error) {
+	return NewPeopleDetector()
+}
+
+func (d *hogDetector) Close() {
+	d.hog.Close()
+}
@@ -0,0 +1,74 @@
+package detection
+
+import (
+	"image"
+
+	"gocv.io/x/gocv"
+)
+
+// MotionDetector finds moving regions in a scene using background subtraction.
+// It learns the background from the frames it sees, so each camera needs its own instance.
+type MotionDetector struct {
+	subtractor gocv.BackgroundSubtractorMOG2
+	kernel     gocv.Mat
+	mask       gocv.Mat
+	minArea    float64
+}
+
+// NewMotionDetector creates a MotionDetector. Sensitivity goes from 0 to 1,
+// higher values pick up fainter changes. Regions smaller than minArea pixels are ignored.
+func NewMotionDetector(sensitivity float32, minArea int) *MotionDetector {
+	// MOG2 compares pixels to the background model using a squared Mahalanobis
+	// distance, OpenCV's default threshold of 16 maps to a sensitivity of 0.8
+	varThreshold := 4 + float64(1-sensitivity)*60
+	return &MotionDetector{
+		subtractor: gocv.NewBackgroundSubtractorMOG2WithParams(500, varThreshold, true),
+		kernel:     gocv.GetStructuringElement(gocv.MorphRect, image.Pt(3, 3)),
+		mask:       gocv.NewMat(),
+		minArea:    float64(minArea),
+	}
+}
+
+// Detect updates the background model with img and returns the moving regions,
+// labelled motion. The confidence is the share of moving pixels in the region.
+func (d *MotionDetector) Detect(img gocv.Mat) []Result {
+	if err := d.subtractor.Apply(img, &d.mask); err != nil {
+		return nil
+	}
+	// shadows are marked as 127 in the mask, only keep real foreground
+	gocv.Threshold(d.mask, &d.mask, 200, 255, gocv.ThresholdBinary)
+	// remove speckle noise, then merge nearby blobs
+	gocv.MorphologyEx(d.mask, &d.mask, gocv.MorphOpen, d.kernel)
+	gocv.Dilate(d.mask, &d.mask, d.kernel)
+
+	contours := gocv.FindContours(d.mask, gocv.RetrievalExternal, gocv.ChainApproxSimple)
+	defer contours.Close()
+
+	var regions []Result
+	for i := 0; i < contours.Size(); i++ {
+		contour := contours.At(i)
+		if gocv.ContourArea(contour) < d.minArea {
+			continue
+		}
+		box := gocv.BoundingRect(contour)
+		regions = append(regions, Result{Label: "motion", Confidence: d.coverage(box), Box: box})
+	}
+	return regions
+}
+
+// coverage returns the share of foreground pixels of the mask inside box
+func (d *MotionDetector) coverage(box image.Rectangle) float32 {
+	area := box.Dx() * box.Dy()
+	if area == 0 {
+		return 0
+	}
+	region := d.mask.Region(box)
+	defer region.Close()
+	return float32(gocv.CountNonZero(region)) / float32(area)
+}
+
+func (d *MotionDetector) Close() {
+	d.subtractor.Close()
+	d.kernel.Close()
+	d.mask.Close()
+}
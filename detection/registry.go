@@ -0,0 +1,141 @@
+package detection
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gocv.io/x/gocv"
+)
+
+// Registry holds the detectors available to the frame processors, by name.
+// Cameras pick the detectors they run by listing their names.
+type Registry struct {
+	mu        sync.RWMutex
+	detectors map[string]Detector
+	skipped   map[string]bool        // detectors that failed to load, cameras listing them run without them
+	locks     map[string]*sync.Mutex // of the detectors shared with forks
+}
+
+func NewRegistry() *Registry {
+	return &Registry{detectors: make(map[string]Detector), skipped: make(map[string]bool)}
+}
+
+// Register adds a detector under the given name
+func (r *Registry) Register(name string, d Detector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" {
+		return fmt.Errorf("detector name is empty")
+	}
+	if _, ok := r.detectors[name]; ok {
+		return fmt.Errorf("detector %q is already registered", name)
+	}
+	r.detectors[name] = d
+	return nil
+}
+
+// Skip records a detector that couldn't be loaded. Cameras may still list
+// it, they run without it.
+func (r *Registry) Skip(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.skipped[name] = true
+}
+
+// Skipped reports whether name is a detector recorded with Skip
+func (r *Registry) Skipped(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.skipped[name]
+}
+
+// Get returns the detector registered under name
+func (r *Registry) Get(name string) (Detector, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.detectors[name]
+	return d, ok
+}
+
+// Names returns the names of the registered detectors, sorted
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.detectors))
+	for name := range r.detectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns an error if one of the names is neither registered nor
+// skipped
+func (r *Registry) Validate(names []string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, name := range names {
+		if _, ok := r.detectors[name]; !ok && !r.skipped[name] {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+	}
+	return nil
+}
+
+// Fork returns a registry with the same names for a single frame processor.
+// Detectors implementing Forker are forked, the others are shared behind a
+// lock. Closing the fork only closes what it owns.
+func (r *Registry) Fork() (*Registry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.locks == nil {
+		r.locks = make(map[string]*sync.Mutex)
+	}
+
+	fork := NewRegistry()
+	for name := range r.skipped {
+		fork.skipped[name] = true
+	}
+	for name, d := range r.detectors {
+		if forker, ok := d.(Forker); ok {
+			forked, err := forker.Fork()
+			if err != nil {
+				fork.Close()
+				return nil, fmt.Errorf("failed to fork detector %q: %w", name, err)
+			}
+			fork.detectors[name] = forked
+			continue
+		}
+		if r.locks[name] == nil {
+			r.locks[name] = &sync.Mutex{}
+		}
+		fork.detectors[name] = &sharedDetector{detector: d, mu: r.locks[name]}
+	}
+	return fork, nil
+}
+
+// sharedDetector serializes the use of a detector by several registries
+type sharedDetector struct {
+	detector Detector
+	mu       *sync.Mutex
+}
+
+func (d *sharedDetector) Detect(img gocv.Mat) []Result {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.detector.Detect(img)
+}
+
+// Close is a no-op, the detector belongs to the registry it was forked from
+func (d *sharedDetector) Close() {}
+
+// Close closes all registered detectors
+func (r *Registry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, d := range r.detectors {
+		d.Close()
+		delete(r.detectors, name)
+	}
+}
@@ -0,0 +1,106 @@
+package live
+
+import (
+	"sync"
+	"time"
+)
+
+// View selects which rendering of a camera feed is published
+type View int
+
+const (
+	Raw     View = iota // frames as captured by the CameraFeedActor
+	Overlay             // frames with detection boxes drawn by the FrameProcessorActor
+)
+
+// Frame is a JPEG encoded frame of a camera
+type Frame struct {
+	Data      []byte
+	Timestamp time.Time
+}
+
+type key struct {
+	cameraID string
+	view     View
+}
+
+// Hub keeps the latest frame of every camera in memory and fans new frames
+// out to subscribers. Slow subscribers only ever get the most recent frame.
+type Hub struct {
+	mu          sync.RWMutex
+	latest      map[key]Frame
+	subscribers map[key]map[chan Frame]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		latest:      make(map[key]Frame),
+		subscribers: make(map[key]map[chan Frame]struct{}),
+	}
+}
+
+// Publish stores frame as the latest one of the camera and notifies subscribers.
+// The data must not be modified afterwards.
+func (h *Hub) Publish(cameraID string, view View, frame Frame) {
+	k := key{cameraID, view}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latest[k] = frame
+	for ch := range h.subscribers[k] {
+		select {
+		case ch <- frame:
+		default:
+			// drop the stale frame the subscriber hasn't read yet
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- frame
+		}
+	}
+}
+
+// Latest returns the most recent frame of the camera
+func (h *Hub) Latest(cameraID string, view View) (Frame, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	frame, ok := h.latest[key{cameraID, view}]
+	return frame, ok
+}
+
+// Subscribe returns a channel receiving the frames published for the camera
+// and a function to call once done. The channel is closed when the camera is removed.
+func (h *Hub) Subscribe(cameraID string, view View) (<-chan Frame, func()) {
+	k := key{cameraID, view}
+	ch := make(chan Frame, 1)
+
+	h.mu.Lock()
+	if h.subscribers[k] == nil {
+		h.subscribers[k] = make(map[chan Frame]struct{})
+	}
+	h.subscribers[k][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[k][ch]; ok {
+			delete(h.subscribers[k], ch)
+			close(ch)
+		}
+	}
+}
+
+// Remove forgets the frames of a camera and closes its subscriptions
+func (h *Hub) Remove(cameraID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, view := range []View{Raw, Overlay} {
+		k := key{cameraID, view}
+		delete(h.latest, k)
+		for ch := range h.subscribers[k] {
+			close(ch)
+		}
+		delete(h.subscribers, k)
+	}
+}
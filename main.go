@@ -2,24 +2,62 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"runtime"
 	"syscall"
+	"time"
 
 	"github.com/tochemey/goakt/v3/actor"
 	aktlog "github.com/tochemey/goakt/v3/log"
 	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/config"
 	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/live"
+	"github.com/zaibon/surveilsense/proto"
 	"github.com/zaibon/surveilsense/storage"
 	"github.com/zaibon/surveilsense/web"
 )
 
 func main() {
+	configFile := flag.String("config", "", "YAML configuration file, the defaults are used without it")
+	processors := flag.Int("processors", runtime.NumCPU(), "number of frame processors running detection in parallel, overrides the configuration")
+	routing := flag.String("routing", string(actors.CameraHashRouting), "how frames are spread over the processors: camera-hash or round-robin, overrides the configuration")
+	cameras := flag.String("cameras", "cameras.json", "file where the cameras are saved, they are started again from it, overrides the configuration")
+	flag.Parse()
+
 	ctx := context.Background()
 	logger := aktlog.DefaultLogger
 
-	faceDetector := detection.NewFaceDetector("detection/haarcascade_frontalface_default.xml")
-	defer faceDetector.Close()
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
+	// flags given on the command line win over the file and the environment
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "processors":
+			cfg.Processors.Count = *processors
+		case "routing":
+			cfg.Processors.Routing = *routing
+		case "cameras":
+			cfg.CamerasFile = *cameras
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		logger.Fatalf("invalid configuration: %v", err)
+		os.Exit(1)
+	}
+	strategy, _ := actors.ParseRoutingStrategy(cfg.Processors.Routing)
+
+	detectors, err := cfg.NewDetectorRegistry()
+	if err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
 
 	// Create the actor system
 	actorSystem, err := actor.NewActorSystem(
@@ -37,23 +75,83 @@ func main() {
 		os.Exit(1)
 	}
 
-	fs, err := storage.NewFilesystemStorage()
+	backend, err := cfg.NewStorage(ctx)
 	if err != nil {
 		logger.Fatal(err)
 		os.Exit(1)
 	}
 
+	// Latest frames of every camera, for the live feeds of the web UI
+	hub := live.NewHub()
+
 	// Spawn actors
-	// Spawn NotificationActor and StorageActor first to get their PIDs
-	_, _ = actorSystem.Spawn(ctx, "NotificationActor", actors.NewNotificationActor())
-	_, _ = actorSystem.Spawn(ctx, "StorageActor", actors.NewStorageActor(fs))
-	// Spawn FrameProcessorActor with actorSystem, notificationPID, and storagePID
-	frameProcessorPID, _ := actorSystem.Spawn(ctx, "FrameProcessorActor", actors.NewFrameProcessorActor(faceDetector))
-	// Pass actorSystem and frameProcessorPID to CameraFeedActor
-	// _, _ = actorSystem.Spawn(ctx, "CameraFeedActor", actors.NewCameraFeedActor(frameProcessorPID))
+	// The event bus forwards the events of the processors to the actors subscribed to them
+	busPID, err := actorSystem.Spawn(ctx, "EventBus", actors.NewEventBusActor())
+	if err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
+	notificationPID, err := actorSystem.Spawn(ctx, "NotificationActor", cfg.NewNotificationActor())
+	if err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
+	_, _ = actorSystem.Spawn(ctx, "StorageActor", actors.NewStorageActor(backend))
+	analyticsPID, _ := actorSystem.Spawn(ctx, "AnalyticsActor", actors.NewAnalyticsActor())
+	// Spawn the FrameProcessorActors, each with its own detectors, behind a pool routing the frames
+	pool := actors.NewFrameProcessorPool(cfg.Processors.Count, strategy, detectors, func(detectors *detection.Registry) *actors.FrameProcessorActor {
+		return actors.NewFrameProcessorActor(detectors).WithLiveHub(hub).WithEventBus(busPID)
+	})
+	frameProcessorPID, _ := actorSystem.Spawn(ctx, "FrameProcessorActor", pool.WithEventBus(busPID))
+	subscriptions := []*proto.Subscription{
+		{Actor: "NotificationActor"},
+		{Actor: "StorageActor", Filter: &proto.EventFilter{Kinds: []proto.EventKind{proto.EventKind_EVENT_KIND_DETECTION, proto.EventKind_EVENT_KIND_TRACK}}},
+		// the lifecycle events tell it about removed cameras and zones
+		{Actor: "AnalyticsActor", Filter: &proto.EventFilter{Kinds: []proto.EventKind{proto.EventKind_EVENT_KIND_ANALYTICS, proto.EventKind_EVENT_KIND_LIFECYCLE}}},
+		// and the processors about the removed cameras
+		{Actor: "FrameProcessorActor", Filter: &proto.EventFilter{Kinds: []proto.EventKind{proto.EventKind_EVENT_KIND_LIFECYCLE}}},
+	}
+	for _, subscription := range subscriptions {
+		resp, err := actor.Ask(ctx, busPID, &proto.Subscribe{Subscription: subscription}, time.Second)
+		if err != nil {
+			logger.Fatal(err)
+			os.Exit(1)
+		}
+		if result, ok := resp.(*proto.CommandResult); !ok || !result.Ok {
+			logger.Fatalf("failed to subscribe %s to the event bus: %v", subscription.Actor, resp)
+			os.Exit(1)
+		}
+	}
+	// The coordinator runs the CameraFeedActors, sending their frames to the pool
+	coordinatorPID, err := actorSystem.Spawn(ctx, "SystemCoordinator", actors.NewSystemCoordinatorActor(frameProcessorPID, detectors).
+		WithLiveHub(hub).
+		WithEventBus(busPID).
+		WithCameraStore(storage.NewCameraFile(cfg.CamerasFile)))
+	if err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
+	if err := addCameras(ctx, coordinatorPID, cfg.Cameras); err != nil {
+		logger.Fatal(err)
+		os.Exit(1)
+	}
 
-	server := web.NewServer(actorSystem, frameProcessorPID)
-	go server.Start()
+	server := web.NewServer(coordinatorPID, frameProcessorPID, hub, detectors).
+		WithAnalytics(analyticsPID).
+		WithEventBus(busPID).
+		WithNotifications(notificationPID).
+		WithClipsDir(cfg.ClipsDir())
+	go server.Start(cfg.HTTP.Listen)
+
+	// Reload the alert rules file on SIGHUP
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	go func() {
+		for range reloadSignal {
+			// the actor logs the outcome
+			_, _ = actor.Ask(ctx, notificationPID, &proto.ReloadAlertRules{}, time.Second)
+		}
+	}()
 
 	// Wait for interrupt signal to gracefully shutdown
 	interruptSignal := make(chan os.Signal, 1)
@@ -61,5 +159,33 @@ func main() {
 	<-interruptSignal
 
 	_ = actorSystem.Stop(ctx)
+	detectors.Close()
+	_ = backend.Close()
 	os.Exit(0)
 }
+
+// addCameras starts the cameras of the configuration. Cameras saved in the
+// cameras file are already running, with the settings edited since.
+func addCameras(ctx context.Context, coordinatorPID *actor.PID, cameras []config.CameraConfig) error {
+	for _, camera := range cameras {
+		resp, err := actor.Ask(ctx, coordinatorPID, &proto.GetCamera{CameraId: camera.ID}, time.Second)
+		if err != nil {
+			return err
+		}
+		if _, ok := resp.(*proto.CameraInfo); ok {
+			continue
+		}
+		spec, err := camera.Spec()
+		if err != nil {
+			return fmt.Errorf("camera %s: %w", camera.ID, err)
+		}
+		resp, err = actor.Ask(ctx, coordinatorPID, &proto.AddCamera{Camera: spec}, time.Second)
+		if err != nil {
+			return err
+		}
+		if result, ok := resp.(*proto.CommandResult); !ok || !result.Ok {
+			return fmt.Errorf("failed to add camera %s: %v", camera.ID, resp)
+		}
+	}
+	return nil
+}
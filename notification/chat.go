@@ -0,0 +1,195 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+const (
+	chatTimeout    = 10 * time.Second
+	chatMaxRetries = 3
+	// wait before retrying a rate limited request the API gave no delay for
+	chatRetryWait = time.Second
+	// name of the snapshot attached to the chat messages
+	snapshotName = "snapshot.jpg"
+)
+
+// ChatError is an error reply of a chat API
+type ChatError struct {
+	Service    string
+	StatusCode int
+	Message    string
+}
+
+func (e *ChatError) Error() string {
+	return fmt.Sprintf("%s: HTTP %d: %s", e.Service, e.StatusCode, e.Message)
+}
+
+// chatAPI sends the requests of a chat notifier
+type chatAPI struct {
+	service string
+	client  *http.Client // a client with a 10s timeout when nil
+	retries int          // of a rate limited request, 3 when 0, none when negative
+	// message of an error reply and the delay the API asks to wait before
+	// retrying, 0 when it doesn't say
+	replyError func(body []byte) (string, time.Duration)
+}
+
+// send sends the request built by newRequest, again after the Retry-After
+// delay while rate limited (HTTP 429) until ctx is done, and decodes the
+// JSON reply into out when not nil
+func (c chatAPI) send(ctx context.Context, newRequest func() (*http.Request, error), out any) error {
+	retries := c.retries
+	if retries == 0 {
+		retries = chatMaxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		wait, err := c.do(ctx, newRequest, out)
+		if wait == 0 || attempt >= retries {
+			return err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (c chatAPI) do(ctx context.Context, newRequest func() (*http.Request, error), out any) (time.Duration, error) {
+	req, err := newRequest()
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	client := c.client
+	if client == nil {
+		client = &http.Client{Timeout: chatTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// the URL may hold a token, e.g. of a webhook or a Telegram bot
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("%s: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil || len(b) == 0 {
+			return 0, nil
+		}
+		if err := json.Unmarshal(b, out); err != nil {
+			return 0, fmt.Errorf("%s: invalid reply: %w", c.service, err)
+		}
+		return 0, nil
+	}
+
+	chatErr := &ChatError{Service: c.service, StatusCode: resp.StatusCode}
+	var wait time.Duration
+	if c.replyError != nil {
+		chatErr.Message, wait = c.replyError(b)
+	}
+	if chatErr.Message == "" {
+		chatErr.Message = strings.TrimSpace(string(b))
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, chatErr
+	}
+	if wait == 0 {
+		wait = retryAfter(resp.Header.Get("Retry-After"), chatRetryWait)
+	}
+	return min(wait, maxRetryWait), chatErr
+}
+
+// jsonRequest returns a request builder posting v as JSON
+func jsonRequest(method, endpoint string, v any) (func() (*http.Request, error), error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return func() (*http.Request, error) {
+		req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}, nil
+}
+
+// multipartRequest returns a request builder posting fields and the
+// snapshot as a multipart form, the snapshot under the field file
+func multipartRequest(endpoint string, fields map[string]string, file string, snapshot []byte) (func() (*http.Request, error), error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	// sorted to send the same form every time
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := w.WriteField(name, fields[name]); err != nil {
+			return nil, err
+		}
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, file, snapshotName))
+	header.Set("Content-Type", "image/jpeg")
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(snapshot); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req, nil
+	}, nil
+}
+
+// chatLines details an event for a chat message, one fact per line
+func chatLines(event *proto.DetectionEvent) []string {
+	lines := []string{
+		"Camera: " + event.CameraId,
+		"Time: " + time.UnixMilli(event.Timestamp).Format("2006-01-02 15:04:05 MST"),
+		"Detections: " + summarize(event),
+	}
+	var zones []string
+	for _, d := range event.Detections {
+		for _, zone := range d.Zones {
+			if !slices.Contains(zones, zone) {
+				zones = append(zones, zone)
+			}
+		}
+	}
+	if len(zones) > 0 {
+		lines = append(lines, "Zones: "+strings.Join(zones, ", "))
+	}
+	if agg := event.Aggregate; agg != nil && agg.Events > 1 {
+		lines = append(lines, "Seen since "+time.UnixMilli(agg.Since).Format("15:04:05")+": "+strings.Join(agg.Labels, ", "))
+	}
+	return lines
+}
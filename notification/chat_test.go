@@ -0,0 +1,383 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// chatRequest is what a stub chat API received
+type chatRequest struct {
+	method, path, auth string
+	json               map[string]any    // JSON body
+	form               map[string]string // form fields, of a multipart or urlencoded body
+	file               []byte            // uploaded file, multipart or raw
+}
+
+// reply answers the request number n (from 0) with a status and a body
+type reply func(n int, r chatRequest) (int, string)
+
+// chatServer starts a stub chat API recording its requests
+func chatServer(t *testing.T, reply reply) (*httptest.Server, func() []chatRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := chatRequest{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), form: make(map[string]string)}
+		switch contentType := r.Header.Get("Content-Type"); {
+		case strings.HasPrefix(contentType, "application/json"):
+			if err := json.NewDecoder(r.Body).Decode(&req.json); err != nil {
+				t.Errorf("invalid JSON body: %v", err)
+			}
+		case strings.HasPrefix(contentType, "multipart/form-data"):
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("invalid multipart body: %v", err)
+				break
+			}
+			for name, values := range r.MultipartForm.Value {
+				req.form[name] = values[0]
+			}
+			for name, files := range r.MultipartForm.File {
+				f, _ := files[0].Open()
+				req.file, _ = io.ReadAll(f)
+				f.Close()
+				req.form[name] = files[0].Filename
+			}
+		case contentType == "application/x-www-form-urlencoded":
+			r.ParseForm()
+			for name := range r.PostForm {
+				req.form[name] = r.PostForm.Get(name)
+			}
+		default:
+			req.file, _ = io.ReadAll(r.Body)
+		}
+		mu.Lock()
+		n := len(requests)
+		requests = append(requests, req)
+		mu.Unlock()
+
+		status, body := reply(n, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []chatRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func chatEvent(snapshot bool) *proto.DetectionEvent {
+	event := &proto.DetectionEvent{
+		CameraId:   "door",
+		Timestamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli(),
+		Detections: []*proto.Detection{{Label: "person", Confidence: 0.9, Zones: []string{"porch"}}},
+	}
+	if snapshot {
+		event.ImageClip = []byte("jpeg")
+	}
+	return event
+}
+
+// paths lists the method and path of the requests
+func paths(requests []chatRequest) []string {
+	var paths []string
+	for _, r := range requests {
+		paths = append(paths, r.method+" "+r.path)
+	}
+	return paths
+}
+
+func checkPaths(t *testing.T, requests []chatRequest, want ...string) {
+	t.Helper()
+	if got := paths(requests); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("got requests %v, want %v", got, want)
+	}
+}
+
+func ok(int, chatRequest) (int, string) { return http.StatusOK, `{"ok": true}` }
+
+func TestSlackNotifier(t *testing.T) {
+	t.Run("webhook", func(t *testing.T) {
+		server, requests := chatServer(t, func(int, chatRequest) (int, string) { return http.StatusOK, "ok" })
+		slack := &SlackNotifier{WebhookURL: server.URL + "/services/T0/B0/x", ImageURL: func(e *proto.DetectionEvent) string {
+			return "https://surveilsense.example.com/clips/" + e.CameraId
+		}}
+		if err := slack.Notify(context.Background(), chatEvent(true)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /services/T0/B0/x")
+		body := reqs[0].json
+		if !strings.HasPrefix(body["text"].(string), "SurveilSense Alert: ") {
+			t.Errorf("text %q", body["text"])
+		}
+		blocks := body["blocks"].([]any)
+		section := blocks[0].(map[string]any)["text"].(map[string]any)["text"].(string)
+		if !strings.Contains(section, "Camera: door") || !strings.Contains(section, "Zones: porch") {
+			t.Errorf("section %q", section)
+		}
+		if len(blocks) != 2 || blocks[1].(map[string]any)["image_url"] != "https://surveilsense.example.com/clips/door" {
+			t.Errorf("blocks %v, want the section and the linked image", blocks)
+		}
+	})
+
+	t.Run("message to the channel", func(t *testing.T) {
+		server, requests := chatServer(t, ok)
+		slack := &SlackNotifier{Token: "xoxb-1", Channel: "C1", BaseURL: server.URL}
+		if err := slack.Notify(context.Background(), chatEvent(false)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /chat.postMessage")
+		if reqs[0].auth != "Bearer xoxb-1" || reqs[0].json["channel"] != "C1" {
+			t.Errorf("posted with %q to %v", reqs[0].auth, reqs[0].json["channel"])
+		}
+	})
+
+	t.Run("snapshot upload", func(t *testing.T) {
+		var server *httptest.Server
+		server, requests := chatServer(t, func(n int, r chatRequest) (int, string) {
+			switch r.path {
+			case "/files.getUploadURLExternal":
+				return http.StatusOK, `{"ok": true, "upload_url": "` + server.URL + `/upload/F1", "file_id": "F1"}`
+			case "/upload/F1":
+				return http.StatusOK, "OK - 4"
+			}
+			return http.StatusOK, `{"ok": true}`
+		})
+		slack := &SlackNotifier{Token: "xoxb-1", Channel: "C1", BaseURL: server.URL}
+		if err := slack.Notify(context.Background(), chatEvent(true)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /files.getUploadURLExternal", "POST /upload/F1", "POST /files.completeUploadExternal")
+		if reqs[0].form["filename"] != snapshotName || reqs[0].form["length"] != "4" || reqs[0].auth != "Bearer xoxb-1" {
+			t.Errorf("upload URL asked with %v and %q", reqs[0].form, reqs[0].auth)
+		}
+		if string(reqs[1].file) != "jpeg" {
+			t.Errorf("uploaded %q", reqs[1].file)
+		}
+		complete := reqs[2].json
+		files := complete["files"].([]any)
+		if complete["channel_id"] != "C1" || files[0].(map[string]any)["id"] != "F1" || !strings.Contains(complete["initial_comment"].(string), "Camera: door") {
+			t.Errorf("upload completed with %v", complete)
+		}
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		server, _ := chatServer(t, func(int, chatRequest) (int, string) {
+			return http.StatusOK, `{"ok": false, "error": "channel_not_found"}`
+		})
+		slack := &SlackNotifier{Token: "xoxb-1", Channel: "C1", BaseURL: server.URL}
+		var chatErr *ChatError
+		if err := slack.Notify(context.Background(), chatEvent(false)); !errors.As(err, &chatErr) || chatErr.Message != "channel_not_found" {
+			t.Errorf("Notify() = %v, want the channel_not_found ChatError", err)
+		}
+	})
+}
+
+func TestDiscordNotifier(t *testing.T) {
+	t.Run("embed", func(t *testing.T) {
+		server, requests := chatServer(t, func(int, chatRequest) (int, string) { return http.StatusNoContent, "" })
+		discord := &DiscordNotifier{WebhookURL: server.URL + "/api/webhooks/1/token", Username: "SurveilSense"}
+		if err := discord.Notify(context.Background(), chatEvent(false)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /api/webhooks/1/token")
+		embed := reqs[0].json["embeds"].([]any)[0].(map[string]any)
+		if reqs[0].json["username"] != "SurveilSense" || embed["timestamp"] != "2024-01-01T12:00:00Z" || !strings.Contains(embed["description"].(string), "Camera: door") {
+			t.Errorf("posted %v", reqs[0].json)
+		}
+	})
+
+	t.Run("snapshot attached", func(t *testing.T) {
+		server, requests := chatServer(t, func(int, chatRequest) (int, string) { return http.StatusOK, `{}` })
+		discord := &DiscordNotifier{WebhookURL: server.URL + "/api/webhooks/1/token"}
+		if err := discord.Notify(context.Background(), chatEvent(true)); err != nil {
+			t.Fatal(err)
+		}
+		req := requests()[0]
+		var payload struct {
+			Embeds []struct {
+				Image struct {
+					URL string `json:"url"`
+				} `json:"image"`
+			} `json:"embeds"`
+		}
+		if err := json.Unmarshal([]byte(req.form["payload_json"]), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if payload.Embeds[0].Image.URL != "attachment://"+snapshotName || req.form["files[0]"] != snapshotName || string(req.file) != "jpeg" {
+			t.Errorf("posted %v with %q", req.form, req.file)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		server, requests := chatServer(t, func(n int, r chatRequest) (int, string) {
+			if n == 0 {
+				return http.StatusTooManyRequests, `{"message": "You are being rate limited.", "retry_after": 0.1}`
+			}
+			return http.StatusNoContent, ""
+		})
+		discord := &DiscordNotifier{WebhookURL: server.URL + "/api/webhooks/1/token"}
+		start := time.Now()
+		if err := discord.Notify(context.Background(), chatEvent(false)); err != nil {
+			t.Fatal(err)
+		}
+		if len(requests()) != 2 || time.Since(start) < 100*time.Millisecond {
+			t.Errorf("sent %d requests in %s, want 2 after the 100ms retry_after", len(requests()), time.Since(start))
+		}
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		server, _ := chatServer(t, func(int, chatRequest) (int, string) {
+			return http.StatusNotFound, `{"message": "Unknown Webhook", "code": 10015}`
+		})
+		discord := &DiscordNotifier{WebhookURL: server.URL + "/api/webhooks/1/token"}
+		var chatErr *ChatError
+		err := discord.Notify(context.Background(), chatEvent(false))
+		if !errors.As(err, &chatErr) || chatErr.StatusCode != http.StatusNotFound || chatErr.Message != "Unknown Webhook" {
+			t.Errorf("Notify() = %v, want the Unknown Webhook ChatError", err)
+		}
+		if strings.Contains(err.Error(), "token") {
+			t.Errorf("error %q leaks the webhook URL", err)
+		}
+	})
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	t.Run("message", func(t *testing.T) {
+		server, requests := chatServer(t, ok)
+		telegram := &TelegramNotifier{BotToken: "123:abc", ChatID: "-100", BaseURL: server.URL}
+		if err := telegram.Notify(context.Background(), chatEvent(false)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /bot123:abc/sendMessage")
+		body := reqs[0].json
+		if body["chat_id"] != "-100" || body["parse_mode"] != "HTML" || !strings.HasPrefix(body["text"].(string), "<b>SurveilSense Alert: ") {
+			t.Errorf("sent %v", body)
+		}
+	})
+
+	t.Run("photo", func(t *testing.T) {
+		server, requests := chatServer(t, ok)
+		telegram := &TelegramNotifier{BotToken: "123:abc", ChatID: "-100", BaseURL: server.URL}
+		if err := telegram.Notify(context.Background(), chatEvent(true)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		checkPaths(t, reqs, "POST /bot123:abc/sendPhoto")
+		if reqs[0].form["chat_id"] != "-100" || !strings.Contains(reqs[0].form["caption"], "Camera: door") || string(reqs[0].file) != "jpeg" {
+			t.Errorf("sent %v with %q", reqs[0].form, reqs[0].file)
+		}
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		server, _ := chatServer(t, func(int, chatRequest) (int, string) {
+			return http.StatusBadRequest, `{"ok": false, "error_code": 400, "description": "Bad Request: chat not found"}`
+		})
+		telegram := &TelegramNotifier{BotToken: "123:abc", ChatID: "-100", BaseURL: server.URL}
+		var chatErr *ChatError
+		err := telegram.Notify(context.Background(), chatEvent(false))
+		if !errors.As(err, &chatErr) || chatErr.Message != "Bad Request: chat not found" {
+			t.Errorf("Notify() = %v, want the chat not found ChatError", err)
+		}
+	})
+
+	t.Run("rate limit beyond the context", func(t *testing.T) {
+		server, requests := chatServer(t, func(int, chatRequest) (int, string) {
+			return http.StatusTooManyRequests, `{"ok": false, "description": "Too Many Requests: retry after 30", "parameters": {"retry_after": 30}}`
+		})
+		telegram := &TelegramNotifier{BotToken: "123:abc", ChatID: "-100", BaseURL: server.URL}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		if err := telegram.Notify(ctx, chatEvent(false)); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Notify() = %v, want the context deadline", err)
+		}
+		if len(requests()) != 1 {
+			t.Errorf("sent %d requests, want 1", len(requests()))
+		}
+	})
+}
+
+func TestMatrixNotifier(t *testing.T) {
+	t.Run("message and snapshot", func(t *testing.T) {
+		server, requests := chatServer(t, func(n int, r chatRequest) (int, string) {
+			if strings.HasPrefix(r.path, "/_matrix/media/") {
+				return http.StatusOK, `{"content_uri": "mxc://example.org/abc"}`
+			}
+			return http.StatusOK, `{"event_id": "$1"}`
+		})
+		matrix := &MatrixNotifier{Homeserver: server.URL + "/", AccessToken: "syt_1", RoomID: "!room:example.org"}
+		if err := matrix.Notify(context.Background(), chatEvent(true)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		if len(reqs) != 3 {
+			t.Fatalf("got requests %v, want the message, the upload and the image", paths(reqs))
+		}
+		for i, r := range reqs {
+			if r.auth != "Bearer syt_1" {
+				t.Errorf("request %d authorized with %q", i, r.auth)
+			}
+		}
+		if reqs[0].method != http.MethodPut || !strings.HasPrefix(reqs[0].path, "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/") {
+			t.Errorf("message sent with %s %s", reqs[0].method, reqs[0].path)
+		}
+		if reqs[0].json["msgtype"] != "m.text" || !strings.Contains(reqs[0].json["formatted_body"].(string), "<b>SurveilSense Alert: ") {
+			t.Errorf("message %v", reqs[0].json)
+		}
+		if reqs[1].path != "/_matrix/media/v3/upload" || string(reqs[1].file) != "jpeg" {
+			t.Errorf("uploaded %q to %s", reqs[1].file, reqs[1].path)
+		}
+		if reqs[2].json["msgtype"] != "m.image" || reqs[2].json["url"] != "mxc://example.org/abc" {
+			t.Errorf("image %v", reqs[2].json)
+		}
+		if reqs[0].path == reqs[2].path {
+			t.Error("the message and the image have the same transaction ID")
+		}
+	})
+
+	t.Run("rate limited with the same transaction", func(t *testing.T) {
+		server, requests := chatServer(t, func(n int, r chatRequest) (int, string) {
+			if n == 0 {
+				return http.StatusTooManyRequests, `{"errcode": "M_LIMIT_EXCEEDED", "error": "Too many requests", "retry_after_ms": 50}`
+			}
+			return http.StatusOK, `{"event_id": "$1"}`
+		})
+		matrix := &MatrixNotifier{Homeserver: server.URL, AccessToken: "syt_1", RoomID: "!room:example.org"}
+		if err := matrix.Notify(context.Background(), chatEvent(false)); err != nil {
+			t.Fatal(err)
+		}
+		reqs := requests()
+		if len(reqs) != 2 || reqs[0].path != reqs[1].path {
+			t.Errorf("got requests %v, want the message sent again with its transaction ID", paths(reqs))
+		}
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		server, _ := chatServer(t, func(int, chatRequest) (int, string) {
+			return http.StatusForbidden, `{"errcode": "M_FORBIDDEN", "error": "You are not in this room"}`
+		})
+		matrix := &MatrixNotifier{Homeserver: server.URL, AccessToken: "syt_1", RoomID: "!room:example.org"}
+		var chatErr *ChatError
+		err := matrix.Notify(context.Background(), chatEvent(false))
+		if !errors.As(err, &chatErr) || chatErr.Message != "M_FORBIDDEN: You are not in this room" {
+			t.Errorf("Notify() = %v, want the M_FORBIDDEN ChatError", err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// discordAlertColor is the color of the embed of an alert, red
+const discordAlertColor = 0xd32f2f
+
+// DiscordNotifier implements Notifier for a Discord channel webhook. The
+// snapshot is attached to the message and shown in its embed.
+type DiscordNotifier struct {
+	WebhookURL string       // https://discord.com/api/webhooks/<id>/<token>, or a compatible API
+	Username   string       // overrides the name of the webhook when set
+	Client     *http.Client // a client with a 10s timeout when nil
+	MaxRetries int          // retries of a rate limited request, 3 when 0, none when negative
+}
+
+// Notify posts the alert as an embed, with the snapshot as its image
+func (d *DiscordNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	embed := map[string]any{
+		"title":       "SurveilSense Alert: " + headline(event),
+		"description": strings.Join(chatLines(event), "\n"),
+		"color":       discordAlertColor,
+		"timestamp":   time.UnixMilli(event.Timestamp).UTC().Format(time.RFC3339),
+	}
+	payload := map[string]any{"embeds": []any{embed}}
+	if d.Username != "" {
+		payload["username"] = d.Username
+	}
+	if len(event.ImageClip) == 0 {
+		req, err := jsonRequest(http.MethodPost, d.WebhookURL, payload)
+		if err != nil {
+			return err
+		}
+		return d.api().send(ctx, req, nil)
+	}
+
+	embed["image"] = map[string]any{"url": "attachment://" + snapshotName}
+	payload["attachments"] = []any{map[string]any{"id": 0, "filename": snapshotName}}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := multipartRequest(d.WebhookURL, map[string]string{"payload_json": string(payloadJSON)}, "files[0]", event.ImageClip)
+	if err != nil {
+		return err
+	}
+	return d.api().send(ctx, req, nil)
+}
+
+func (d *DiscordNotifier) api() chatAPI {
+	return chatAPI{service: "discord", client: d.Client, retries: d.MaxRetries, replyError: func(body []byte) (string, time.Duration) {
+		var reply struct {
+			Message    string  `json:"message"`
+			RetryAfter float64 `json:"retry_after"` // seconds
+		}
+		if json.Unmarshal(body, &reply) != nil {
+			return "", 0
+		}
+		return reply.Message, time.Duration(reply.RetryAfter * float64(time.Second))
+	}}
+}
@@ -1,6 +1,7 @@
 package notification
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/smtp"
@@ -21,9 +22,11 @@ type EmailNotifier struct {
 	UseTLS     bool
 }
 
-func (e *EmailNotifier) Notify(event *proto.DetectionEvent) error {
-	subject := fmt.Sprintf("SurveilSense Alert: Detection on camera %s", event.CameraId)
-	body := fmt.Sprintf("Detection event for camera %s at %d. Detections: %d", event.CameraId, event.Timestamp, len(event.Detections))
+// Notify sends the alert to every recipient in one message. net/smtp has no
+// context, the exchange isn't cut short when ctx is done.
+func (e *EmailNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	subject := "SurveilSense Alert: " + headline(event)
+	body := describe(event)
 	msg := "From: " + e.From + "\r\n" +
 		"To: " + strings.Join(e.To, ",") + "\r\n" +
 		"Subject: " + subject + "\r\n" +
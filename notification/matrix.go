@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// matrixTxn numbers the events sent by the MatrixNotifiers, the homeserver
+// ignores an event sent again with the same transaction ID
+var matrixTxn atomic.Int64
+
+// MatrixNotifier implements Notifier for a Matrix room, through the
+// client-server API. The alert is sent as a formatted message followed by
+// the snapshot as an image.
+type MatrixNotifier struct {
+	Homeserver  string // base URL of the client-server API, e.g. https://matrix.example.org
+	AccessToken string
+	RoomID      string       // !room:example.org
+	Client      *http.Client // a client with a 10s timeout when nil
+	MaxRetries  int          // retries of a rate limited request, 3 when 0, none when negative
+}
+
+// Notify sends the message then the snapshot to the room
+func (m *MatrixNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	title := "SurveilSense Alert: " + headline(event)
+	lines := chatLines(event)
+	formatted := make([]string, len(lines))
+	for i, line := range lines {
+		formatted[i] = html.EscapeString(line)
+	}
+	if err := m.send(ctx, map[string]any{
+		"msgtype":        "m.text",
+		"body":           title + "\n" + strings.Join(lines, "\n"),
+		"format":         "org.matrix.custom.html",
+		"formatted_body": "<b>" + html.EscapeString(title) + "</b><br>" + strings.Join(formatted, "<br>"),
+	}); err != nil {
+		return err
+	}
+	if len(event.ImageClip) == 0 {
+		return nil
+	}
+
+	uri, err := m.upload(ctx, event.ImageClip)
+	if err != nil {
+		return err
+	}
+	return m.send(ctx, map[string]any{
+		"msgtype": "m.image",
+		"body":    snapshotName,
+		"url":     uri,
+		"info":    map[string]any{"mimetype": "image/jpeg", "size": len(event.ImageClip)},
+	})
+}
+
+// upload stores the snapshot in the media repository, it returns its mxc:// URI
+func (m *MatrixNotifier) upload(ctx context.Context, snapshot []byte) (string, error) {
+	endpoint := m.endpoint("/_matrix/media/v3/upload") + "?filename=" + url.QueryEscape(snapshotName)
+	var reply struct {
+		ContentURI string `json:"content_uri"`
+	}
+	err := m.api().send(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(snapshot))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "image/jpeg")
+		req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+		return req, nil
+	}, &reply)
+	if err != nil {
+		return "", err
+	}
+	if reply.ContentURI == "" {
+		return "", fmt.Errorf("matrix: upload replied without content_uri")
+	}
+	return reply.ContentURI, nil
+}
+
+// send sends an m.room.message event to the room. Its transaction ID is
+// kept across the retries so that the message isn't duplicated.
+func (m *MatrixNotifier) send(ctx context.Context, content map[string]any) error {
+	txn := fmt.Sprintf("surveilsense-%d-%d", time.Now().UnixMilli(), matrixTxn.Add(1))
+	endpoint := m.endpoint(fmt.Sprintf("/_matrix/client/v3/rooms/%s/send/m.room.message/%s", url.PathEscape(m.RoomID), txn))
+	newRequest, err := jsonRequest(http.MethodPut, endpoint, content)
+	if err != nil {
+		return err
+	}
+	return m.api().send(ctx, func() (*http.Request, error) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+		return req, nil
+	}, nil)
+}
+
+func (m *MatrixNotifier) endpoint(path string) string {
+	return strings.TrimSuffix(m.Homeserver, "/") + path
+}
+
+func (m *MatrixNotifier) api() chatAPI {
+	return chatAPI{service: "matrix", client: m.Client, retries: m.MaxRetries, replyError: func(body []byte) (string, time.Duration) {
+		var reply struct {
+			ErrCode      string `json:"errcode"`
+			Error        string `json:"error"`
+			RetryAfterMs int64  `json:"retry_after_ms"`
+		}
+		if json.Unmarshal(body, &reply) != nil || reply.ErrCode == "" {
+			return "", 0
+		}
+		return reply.ErrCode + ": " + reply.Error, time.Duration(reply.RetryAfterMs) * time.Millisecond
+	}}
+}
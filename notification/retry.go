@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryWait is the longest Retry-After honoured, a notification isn't
+// worth waiting longer
+const maxRetryWait = 30 * time.Second
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP
+// date, fallback when it is missing or invalid
+func retryAfter(v string, fallback time.Duration) time.Duration {
+	wait := fallback
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil && time.Until(t) > 0 {
+		wait = time.Until(t)
+	}
+	return min(wait, maxRetryWait)
+}
+
+// sleep waits for d, or until ctx is done in which case it returns its error
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
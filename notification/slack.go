@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// DefaultSlackBaseURL is the Web API the SlackNotifier posts to with a bot
+// token when BaseURL is empty
+const DefaultSlackBaseURL = "https://slack.com/api"
+
+// SlackNotifier implements Notifier for Slack. With a bot Token the
+// snapshot is uploaded to the Channel along with the message. Incoming
+// webhooks can't upload files, with a WebhookURL the snapshot is linked
+// from ImageURL when set.
+type SlackNotifier struct {
+	WebhookURL string
+	Token      string // bot token with the chat:write and files:write scopes
+	Channel    string // channel ID, with Token
+	BaseURL    string // DefaultSlackBaseURL when empty
+	// ImageURL returns the public link to the image of an event, with WebhookURL
+	ImageURL   func(event *proto.DetectionEvent) string
+	Client     *http.Client // a client with a 10s timeout when nil
+	MaxRetries int          // retries of a rate limited request, 3 when 0, none when negative
+}
+
+// slackReply is the reply of the Web API, errors come with HTTP 200
+type slackReply struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+func (r slackReply) err() error {
+	if r.OK {
+		return nil
+	}
+	return &ChatError{Service: "slack", StatusCode: http.StatusOK, Message: r.Error}
+}
+
+// Notify posts the alert to the webhook or, with a token, to the channel
+func (s *SlackNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	text, blocks := s.message(event)
+	if s.Token == "" {
+		if link := s.imageURL(event); link != "" {
+			blocks = append(blocks, map[string]any{"type": "image", "image_url": link, "alt_text": headline(event)})
+		}
+		req, err := jsonRequest(http.MethodPost, s.WebhookURL, map[string]any{"text": text, "blocks": blocks})
+		if err != nil {
+			return err
+		}
+		return s.api().send(ctx, req, nil)
+	}
+	if len(event.ImageClip) == 0 {
+		req, err := s.webAPI("chat.postMessage", map[string]any{"channel": s.Channel, "text": text, "blocks": blocks})
+		if err != nil {
+			return err
+		}
+		var reply slackReply
+		if err := s.api().send(ctx, req, &reply); err != nil {
+			return err
+		}
+		return reply.err()
+	}
+	return s.upload(ctx, event, text)
+}
+
+func (s *SlackNotifier) imageURL(event *proto.DetectionEvent) string {
+	if s.ImageURL == nil || len(event.ImageClip) == 0 {
+		return ""
+	}
+	return s.ImageURL(event)
+}
+
+// message renders the alert in mrkdwn, as plain text for the notification
+// and as blocks for the channel
+func (s *SlackNotifier) message(event *proto.DetectionEvent) (string, []any) {
+	text := "SurveilSense Alert: " + headline(event)
+	lines := chatLines(event)
+	for i, line := range lines {
+		lines[i] = slackEscape(line)
+	}
+	blocks := []any{
+		map[string]any{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": "*" + slackEscape(text) + "*\n" + strings.Join(lines, "\n")}},
+	}
+	return text, blocks
+}
+
+// upload shares the snapshot in the channel with the message as its
+// comment: the file is sent to the URL given by files.getUploadURLExternal,
+// then shared by files.completeUploadExternal
+func (s *SlackNotifier) upload(ctx context.Context, event *proto.DetectionEvent, text string) error {
+	form := url.Values{"filename": {snapshotName}, "length": {strconv.Itoa(len(event.ImageClip))}}.Encode()
+	var target struct {
+		slackReply
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	err := s.api().send(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, s.endpoint("files.getUploadURLExternal"), strings.NewReader(form))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+		return req, nil
+	}, &target)
+	if err != nil {
+		return err
+	}
+	if err := target.err(); err != nil {
+		return err
+	}
+
+	send, err := multipartRequest(target.UploadURL, nil, "file", event.ImageClip)
+	if err != nil {
+		return err
+	}
+	if err := s.api().send(ctx, send, nil); err != nil {
+		return err
+	}
+
+	complete, err := s.webAPI("files.completeUploadExternal", map[string]any{
+		"files":           []any{map[string]any{"id": target.FileID, "title": fmt.Sprintf("%s %s", event.CameraId, time.UnixMilli(event.Timestamp).Format(time.DateTime))}},
+		"channel_id":      s.Channel,
+		"initial_comment": text + "\n" + strings.Join(chatLines(event), "\n"),
+	})
+	if err != nil {
+		return err
+	}
+	var reply slackReply
+	if err := s.api().send(ctx, complete, &reply); err != nil {
+		return err
+	}
+	return reply.err()
+}
+
+// webAPI returns a request builder calling a Web API method with a JSON body
+func (s *SlackNotifier) webAPI(method string, body map[string]any) (func() (*http.Request, error), error) {
+	newRequest, err := jsonRequest(http.MethodPost, s.endpoint(method), body)
+	if err != nil {
+		return nil, err
+	}
+	return func() (*http.Request, error) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+		return req, nil
+	}, nil
+}
+
+func (s *SlackNotifier) endpoint(method string) string {
+	base := s.BaseURL
+	if base == "" {
+		base = DefaultSlackBaseURL
+	}
+	return strings.TrimSuffix(base, "/") + "/" + method
+}
+
+func (s *SlackNotifier) api() chatAPI {
+	return chatAPI{service: "slack", client: s.Client, retries: s.MaxRetries, replyError: func(body []byte) (string, time.Duration) {
+		var reply slackReply
+		if json.Unmarshal(body, &reply) == nil {
+			return reply.Error, 0
+		}
+		return "", 0
+	}}
+}
+
+// slackEscape escapes the control characters of mrkdwn
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
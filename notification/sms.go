@@ -1,35 +1,141 @@
 package notification
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/zaibon/surveilsense/proto"
 )
 
-// SMSNotifier implements Notifier for SMS notifications
-// Integrate with your SMS provider (e.g., Twilio) in the SendSMS method
+// DefaultTwilioBaseURL is the API the SMSNotifier sends to when BaseURL is empty
+const DefaultTwilioBaseURL = "https://api.twilio.com"
 
+const (
+	smsTimeout    = 10 * time.Second
+	smsMaxRetries = 3
+	// wait before retrying a rate limited request without Retry-After
+	smsRetryWait = time.Second
+)
+
+// SMSNotifier implements Notifier for SMS notifications, sent through the
+// Twilio Messages API or any API compatible with it
 type SMSNotifier struct {
-	AccountSID string // For Twilio or similar
+	AccountSID string
 	AuthToken  string
 	From       string
 	To         []string
-	// Add other provider-specific fields as needed
+	BaseURL    string       // DefaultTwilioBaseURL when empty
+	Client     *http.Client // a client with a 10s timeout when nil
+	MaxRetries int          // retries of a rate limited message, 3 when 0, none when negative
+}
+
+// RecipientError is the failure to send a message to one recipient
+type RecipientError struct {
+	Recipient string
+	Err       error
+}
+
+func (e *RecipientError) Error() string {
+	return fmt.Sprintf("failed to send SMS to %s: %v", e.Recipient, e.Err)
+}
+
+func (e *RecipientError) Unwrap() error {
+	return e.Err
 }
 
-func (s *SMSNotifier) Notify(event *proto.DetectionEvent) error {
-	body := fmt.Sprintf("SurveilSense Alert: Detection on camera %s at %d. Detections: %d", event.CameraId, event.Timestamp, len(event.Detections))
+// APIError is an error reply of the Messages API
+type APIError struct {
+	StatusCode int    `json:"status"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	MoreInfo   string `json:"more_info"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("twilio error %d (HTTP %d): %s", e.Code, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// Notify sends the alert to every recipient. A failing recipient doesn't
+// stop the others, the returned error joins a RecipientError per failure.
+func (s *SMSNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	body := "SurveilSense Alert: " + describe(event)
+	var errs []error
 	for _, recipient := range s.To {
-		if err := s.SendSMS(recipient, body); err != nil {
-			return fmt.Errorf("failed to send SMS to %s: %w", recipient, err)
+		if err := s.SendSMS(ctx, recipient, body); err != nil {
+			errs = append(errs, &RecipientError{Recipient: recipient, Err: err})
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
-// SendSMS is a placeholder for actual SMS sending logic (e.g., via Twilio API)
-func (s *SMSNotifier) SendSMS(to, body string) error {
-	// TODO: Integrate with SMS provider API
-	fmt.Printf("[SMS] Would send to %s: %s\n", to, body)
-	return nil
+// SendSMS sends body to a phone number. Rate limited requests (HTTP 429)
+// are retried after the Retry-After delay, unless ctx is done first.
+func (s *SMSNotifier) SendSMS(ctx context.Context, to, body string) error {
+	base := s.BaseURL
+	if base == "" {
+		base = DefaultTwilioBaseURL
+	}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimSuffix(base, "/"), url.PathEscape(s.AccountSID))
+	form := url.Values{"To": {to}, "From": {s.From}, "Body": {body}}.Encode()
+
+	retries := s.MaxRetries
+	if retries == 0 {
+		retries = smsMaxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		wait, err := s.post(ctx, endpoint, form)
+		if wait == 0 || attempt >= retries {
+			return err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// post sends one request. It returns how long to wait before trying again
+// when rate limited, 0 otherwise.
+func (s *SMSNotifier) post(ctx context.Context, endpoint, form string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form))
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth(s.AccountSID, s.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: smsTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	apiErr := &APIError{}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(b, apiErr) != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(b))
+	}
+	apiErr.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, apiErr
+	}
+	return retryAfter(resp.Header.Get("Retry-After"), smsRetryWait), apiErr
 }
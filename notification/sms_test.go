@@ -0,0 +1,182 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// smsRequest is what the stub Messages API received
+type smsRequest struct {
+	path, user, password, to, from, body string
+}
+
+func TestSMSNotifier(t *testing.T) {
+	event := &proto.DetectionEvent{CameraId: "door", Timestamp: time.Now().UnixMilli(), Detections: []*proto.Detection{{Label: "person", Confidence: 0.9}}}
+	tests := []struct {
+		name string
+		to   []string
+		// reply answers the request number n (from 0) of a recipient
+		reply       func(w http.ResponseWriter, to string, n int)
+		maxRetries  int
+		timeout     time.Duration // of the context, none when 0
+		wantSent    []string      // recipients, in order of the requests
+		wantFailed  []string
+		wantErr     error
+		wantElapsed time.Duration // at least
+	}{
+		{
+			name:     "sent to every recipient",
+			to:       []string{"+15550001", "+15550002"},
+			reply:    func(w http.ResponseWriter, to string, n int) { w.WriteHeader(http.StatusCreated) },
+			wantSent: []string{"+15550001", "+15550002"},
+		},
+		{
+			name: "rate limited then sent",
+			to:   []string{"+15550001"},
+			reply: func(w http.ResponseWriter, to string, n int) {
+				if n == 0 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					w.Write([]byte(`{"code": 20429, "message": "Too Many Requests", "status": 429}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			},
+			wantSent:    []string{"+15550001", "+15550001"},
+			wantElapsed: time.Second,
+		},
+		{
+			name: "rate limited beyond the retries",
+			to:   []string{"+15550001"},
+			reply: func(w http.ResponseWriter, to string, n int) {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			maxRetries: 1,
+			wantSent:   []string{"+15550001", "+15550001"},
+			wantFailed: []string{"+15550001"},
+		},
+		{
+			name: "retry given up with the context",
+			to:   []string{"+15550001"},
+			reply: func(w http.ResponseWriter, to string, n int) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			timeout:    100 * time.Millisecond,
+			wantSent:   []string{"+15550001"},
+			wantFailed: []string{"+15550001"},
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name: "a failing recipient doesn't stop the others",
+			to:   []string{"+15550001", "invalid", "+15550002"},
+			reply: func(w http.ResponseWriter, to string, n int) {
+				if to == "invalid" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"code": 21211, "message": "The 'To' number is not valid.", "status": 400}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			},
+			wantSent:   []string{"+15550001", "invalid", "+15550002"},
+			wantFailed: []string{"invalid"},
+			wantErr:    &APIError{StatusCode: http.StatusBadRequest, Code: 21211, Message: "The 'To' number is not valid."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []smsRequest
+			attempts := make(map[string]int)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, password, _ := r.BasicAuth()
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("invalid form: %v", err)
+				}
+				req := smsRequest{path: r.URL.Path, user: user, password: password, to: r.PostForm.Get("To"), from: r.PostForm.Get("From"), body: r.PostForm.Get("Body")}
+				mu.Lock()
+				requests = append(requests, req)
+				n := attempts[req.to]
+				attempts[req.to]++
+				mu.Unlock()
+				tt.reply(w, req.to, n)
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			sms := &SMSNotifier{AccountSID: "AC123", AuthToken: "secret", From: "+15559999", To: tt.to, BaseURL: server.URL + "/", MaxRetries: tt.maxRetries}
+			start := time.Now()
+			err := sms.Notify(ctx, event)
+			if elapsed := time.Since(start); elapsed < tt.wantElapsed {
+				t.Errorf("notified in %s, want at least %s", elapsed, tt.wantElapsed)
+			}
+
+			var sent []string
+			for _, req := range requests {
+				sent = append(sent, req.to)
+				if req.path != "/2010-04-01/Accounts/AC123/Messages.json" {
+					t.Errorf("sent to %s", req.path)
+				}
+				if req.user != "AC123" || req.password != "secret" {
+					t.Errorf("authenticated as %q:%q, want the account SID and auth token", req.user, req.password)
+				}
+				if req.from != "+15559999" || !strings.HasPrefix(req.body, "SurveilSense Alert: ") {
+					t.Errorf("sent %q from %q", req.body, req.from)
+				}
+			}
+			if !slices.Equal(sent, tt.wantSent) {
+				t.Errorf("sent to %v, want %v", sent, tt.wantSent)
+			}
+
+			var failed []string
+			for _, e := range joined(err) {
+				var recipientErr *RecipientError
+				if !errors.As(e, &recipientErr) {
+					t.Fatalf("got error %v, want RecipientErrors", e)
+				}
+				failed = append(failed, recipientErr.Recipient)
+			}
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed to send to %v, want %v", failed, tt.wantFailed)
+			}
+			switch want := tt.wantErr.(type) {
+			case nil:
+			case *APIError:
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || *apiErr != *want {
+					t.Errorf("got error %v, want %v", err, want)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("got error %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+// joined returns the errors joined in err
+func joined(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		return j.Unwrap()
+	}
+	return []error{err}
+}
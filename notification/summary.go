@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// summarize describes the detections of an event by label, e.g. "2 face, 1 person"
+func summarize(event *proto.DetectionEvent) string {
+	counts := make(map[string]int)
+	var labels []string
+	for _, d := range event.Detections {
+		label := d.Label
+		if label == "" {
+			label = "object"
+		}
+		if counts[label] == 0 {
+			labels = append(labels, label)
+		}
+		counts[label]++
+	}
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[label], label))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// headline describes an event in one line, e.g. "Detection on camera cam-1"
+// or "5 detections on camera cam-1 in the last 2m0s" for an aggregate
+func headline(event *proto.DetectionEvent) string {
+	agg := event.Aggregate
+	if agg == nil || agg.Events <= 1 {
+		return fmt.Sprintf("Detection on camera %s", event.CameraId)
+	}
+	since := time.Duration(event.Timestamp-agg.Since) * time.Millisecond
+	return fmt.Sprintf("%d detections on camera %s in the last %s", agg.Events, event.CameraId, since.Round(time.Second))
+}
+
+// describe details an event, with the labels seen for an aggregate
+func describe(event *proto.DetectionEvent) string {
+	if agg := event.Aggregate; agg != nil && agg.Events > 1 {
+		return fmt.Sprintf("%s (%s). Latest at %d: %s", headline(event), strings.Join(agg.Labels, ", "), event.Timestamp, summarize(event))
+	}
+	return fmt.Sprintf("%s at %d. Detections: %s", headline(event), event.Timestamp, summarize(event))
+}
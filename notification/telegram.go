@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// DefaultTelegramBaseURL is the Bot API the TelegramNotifier calls when
+// BaseURL is empty
+const DefaultTelegramBaseURL = "https://api.telegram.org"
+
+// telegramCaptionLimit is the longest caption of a photo, in characters
+const telegramCaptionLimit = 1024
+
+// TelegramNotifier implements Notifier for a Telegram chat, through the
+// Bot API. The snapshot is sent as a photo captioned with the alert.
+type TelegramNotifier struct {
+	BotToken   string
+	ChatID     string       // numeric ID or @channelusername
+	BaseURL    string       // DefaultTelegramBaseURL when empty
+	Client     *http.Client // a client with a 10s timeout when nil
+	MaxRetries int          // retries of a rate limited request, 3 when 0, none when negative
+}
+
+// telegramError is an error reply of the Bot API
+type telegramError struct {
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"` // seconds
+	} `json:"parameters"`
+}
+
+// Notify sends the alert to the chat, as the caption of the snapshot when
+// there is one
+func (t *TelegramNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	lines := chatLines(event)
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	text := "<b>SurveilSense Alert: " + html.EscapeString(headline(event)) + "</b>\n" + strings.Join(lines, "\n")
+
+	if len(event.ImageClip) == 0 {
+		req, err := jsonRequest(http.MethodPost, t.endpoint("sendMessage"), map[string]any{
+			"chat_id":    t.ChatID,
+			"text":       text,
+			"parse_mode": "HTML",
+		})
+		if err != nil {
+			return err
+		}
+		return t.api().send(ctx, req, nil)
+	}
+
+	fields := map[string]string{"chat_id": t.ChatID, "caption": text, "parse_mode": "HTML"}
+	if len([]rune(text)) > telegramCaptionLimit {
+		// cutting the HTML could leave a tag open, fall back to plain text
+		caption := []rune(headline(event) + "\n" + strings.Join(chatLines(event), "\n"))
+		fields["caption"] = string(caption[:min(len(caption), telegramCaptionLimit)])
+		delete(fields, "parse_mode")
+	}
+	req, err := multipartRequest(t.endpoint("sendPhoto"), fields, "photo", event.ImageClip)
+	if err != nil {
+		return err
+	}
+	return t.api().send(ctx, req, nil)
+}
+
+func (t *TelegramNotifier) endpoint(method string) string {
+	base := t.BaseURL
+	if base == "" {
+		base = DefaultTelegramBaseURL
+	}
+	return fmt.Sprintf("%s/bot%s/%s", strings.TrimSuffix(base, "/"), t.BotToken, method)
+}
+
+func (t *TelegramNotifier) api() chatAPI {
+	return chatAPI{service: "telegram", client: t.Client, retries: t.MaxRetries, replyError: func(body []byte) (string, time.Duration) {
+		var reply telegramError
+		if json.Unmarshal(body, &reply) != nil {
+			return "", 0
+		}
+		return reply.Description, time.Duration(reply.Parameters.RetryAfter) * time.Second
+	}}
+}
@@ -0,0 +1,221 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// Headers of the requests of a WebhookNotifier
+const (
+	WebhookSignatureHeader = "X-SurveilSense-Signature" // sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+	WebhookTimestampHeader = "X-SurveilSense-Timestamp" // unix seconds the request was signed at
+)
+
+const (
+	webhookTimeout        = 10 * time.Second
+	webhookMaxRetries     = 3
+	webhookInitialBackoff = 500 * time.Millisecond
+)
+
+// WebhookImage tells how a WebhookNotifier passes the image of an event
+type WebhookImage string
+
+const (
+	WebhookImageNone   WebhookImage = "none"
+	WebhookImageBase64 WebhookImage = "base64" // inline, in image_clip
+	WebhookImageURL    WebhookImage = "url"    // as a link in image_url
+)
+
+// WebhookNotifier implements Notifier by POSTing the DetectionEvent as JSON.
+// The body is the protobuf JSON form of the event (snake_case fields, times
+// in unix milliseconds) with a one line summary. Unlike protojson, which
+// quotes them, every 64 bit integer (timestamps, track IDs, durations) is a
+// JSON number. Failed requests are retried with an exponential backoff,
+// client errors other than 429 aren't.
+type WebhookNotifier struct {
+	URL     string
+	Secret  string            // signs the requests when set, see WebhookSignatureHeader
+	Headers map[string]string // added to every request, e.g. Authorization
+	Image   WebhookImage      // WebhookImageNone when empty
+	// ImageURL returns the link to the image of an event with WebhookImageURL
+	ImageURL   func(event *proto.DetectionEvent) string
+	Timeout    time.Duration // of each request, 10s when 0
+	MaxRetries int           // 3 when 0, none when negative
+	Client     *http.Client
+}
+
+// Notify posts the event, retrying until it is accepted, the retries are
+// exhausted or ctx is done
+func (n *WebhookNotifier) Notify(ctx context.Context, event *proto.DetectionEvent) error {
+	body, err := n.payload(event)
+	if err != nil {
+		return err
+	}
+
+	retries := n.MaxRetries
+	if retries == 0 {
+		retries = webhookMaxRetries
+	}
+	backoff := webhookInitialBackoff
+	for attempt := 0; ; attempt++ {
+		wait, err := n.post(ctx, body, backoff)
+		if err == nil || wait == 0 || attempt >= retries {
+			return err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// payload renders the event to JSON, with its image as configured
+func (n *WebhookNotifier) payload(event *proto.DetectionEvent) ([]byte, error) {
+	image := event.ImageClip
+	if n.Image != WebhookImageBase64 && len(image) > 0 {
+		event = protobuf.Clone(event).(*proto.DetectionEvent)
+		event.ImageClip = nil
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	int64Numbers(event.ProtoReflect(), fields)
+	fields["summary"] = describe(event)
+	if n.Image == WebhookImageURL && len(image) > 0 && n.ImageURL != nil {
+		fields["image_url"] = n.ImageURL(event)
+	}
+	return json.Marshal(fields)
+}
+
+// int64Numbers turns the 64 bit integers of m, quoted by protojson in
+// fields, back into numbers. fields is the protojson form of m, with the
+// proto field names.
+func int64Numbers(m protoreflect.Message, fields map[string]any) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			items, _ := fields[name].([]any)
+			list := v.List()
+			for i := 0; i < list.Len() && i < len(items); i++ {
+				if fd.Kind() == protoreflect.MessageKind {
+					if item, ok := items[i].(map[string]any); ok {
+						int64Numbers(list.Get(i).Message(), item)
+					}
+				} else if number, ok := int64Number(fd.Kind(), list.Get(i)); ok {
+					items[i] = number
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			if sub, ok := fields[name].(map[string]any); ok {
+				int64Numbers(v.Message(), sub)
+			}
+		default:
+			if number, ok := int64Number(fd.Kind(), v); ok {
+				fields[name] = number
+			}
+		}
+		return true
+	})
+}
+
+// int64Number returns v as a number when kind is a 64 bit integer
+func int64Number(kind protoreflect.Kind, v protoreflect.Value) (any, bool) {
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint(), true
+	}
+	return nil, false
+}
+
+// withoutURL drops the URL a *url.Error repeats, webhook URLs often carry a
+// token in their path or query
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// post sends one request. It returns how long to wait before trying again,
+// 0 when the request must not be retried.
+func (n *WebhookNotifier) post(ctx context.Context, body []byte, backoff time.Duration) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", withoutURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SurveilSense-Webhook")
+	for k, v := range n.Headers {
+		req.Header.Set(k, v)
+	}
+	if n.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(n.Secret, timestamp, body))
+	}
+
+	client := n.Client
+	if client == nil {
+		timeout := n.Timeout
+		if timeout == 0 {
+			timeout = webhookTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return backoff, fmt.Errorf("webhook: %w", withoutURL(err))
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+
+	err = fmt.Errorf("webhook: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		return retryAfter(resp.Header.Get("Retry-After"), backoff), err
+	case resp.StatusCode >= 500:
+		return backoff, err
+	}
+	return 0, err
+}
+
+// SignWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed
+// with secret, for receivers to check the WebhookSignatureHeader
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
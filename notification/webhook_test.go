@@ -0,0 +1,195 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+func TestSignWebhook(t *testing.T) {
+	// computed with Python: hmac.new(b"topsecret", b'1700000000.{"camera_id":"door"}', hashlib.sha256).hexdigest()
+	const want = "c3707fcceaa68617ef854460f8c6eb3ca98c0c54a76a1369cb4fd15f5b96f705"
+	if got := SignWebhook("topsecret", "1700000000", []byte(`{"camera_id":"door"}`)); got != want {
+		t.Errorf("SignWebhook() = %s, want %s", got, want)
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	event := &proto.DetectionEvent{
+		CameraId:  "door",
+		Timestamp: 1700000000123,
+		Detections: []*proto.Detection{
+			{Label: "person", Confidence: 0.9, TrackId: 1700000000000001, FirstSeen: 1700000000000, DwellMs: 123},
+			{Label: "car"},
+		},
+		ImageClip: []byte("jpeg"),
+		Aggregate: &proto.DetectionAggregate{Events: 3, Since: 1699999999000},
+	}
+	tests := []struct {
+		name         string
+		image        WebhookImage
+		wantClip     bool
+		wantImageURL string
+	}{
+		{name: "no image", image: WebhookImageNone},
+		{name: "inline image", image: WebhookImageBase64, wantClip: true},
+		{name: "image link", image: WebhookImageURL, wantImageURL: "https://surveilsense.example.com/clips/door"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &WebhookNotifier{Image: tt.image, ImageURL: func(e *proto.DetectionEvent) string {
+				return "https://surveilsense.example.com/clips/" + e.CameraId
+			}}
+			b, err := n.payload(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.UseNumber()
+			var body struct {
+				Timestamp  any `json:"timestamp"`
+				Detections []struct {
+					TrackID   any `json:"track_id"`
+					FirstSeen any `json:"first_seen"`
+					DwellMs   any `json:"dwell_ms"`
+				} `json:"detections"`
+				Aggregate struct {
+					Events any `json:"events"`
+					Since  any `json:"since"`
+				} `json:"aggregate"`
+				ImageClip string `json:"image_clip"`
+				ImageURL  string `json:"image_url"`
+				Summary   string `json:"summary"`
+			}
+			if err := dec.Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			d := body.Detections[0]
+			for name, got := range map[string]any{
+				"timestamp":        body.Timestamp,
+				"track_id":         d.TrackID,
+				"first_seen":       d.FirstSeen,
+				"dwell_ms":         d.DwellMs,
+				"aggregate.since":  body.Aggregate.Since,
+				"aggregate.events": body.Aggregate.Events,
+			} {
+				if _, ok := got.(json.Number); !ok {
+					t.Errorf("%s is %#v, want a number", name, got)
+				}
+			}
+			if got := body.Detections[0].TrackID.(json.Number).String(); got != "1700000000000001" {
+				t.Errorf("track_id is %s, want 1700000000000001", got)
+			}
+			if body.Detections[1].TrackID != nil {
+				t.Errorf("track_id of an untracked detection is %v, want none", body.Detections[1].TrackID)
+			}
+			if (body.ImageClip != "") != tt.wantClip {
+				t.Errorf("image_clip is %q, want it: %v", body.ImageClip, tt.wantClip)
+			}
+			if body.ImageURL != tt.wantImageURL {
+				t.Errorf("image_url is %q, want %q", body.ImageURL, tt.wantImageURL)
+			}
+			if body.Summary == "" {
+				t.Error("no summary")
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	event := &proto.DetectionEvent{CameraId: "door", Timestamp: time.Now().UnixMilli(), Detections: []*proto.Detection{{Label: "person"}}}
+	tests := []struct {
+		name         string
+		statuses     []int // replied in turn, the last one repeats
+		retryAfter   string
+		timeout      time.Duration // of the context, none when 0
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "accepted", statuses: []int{http.StatusNoContent}, wantRequests: 1},
+		{name: "server error retried", statuses: []int{http.StatusBadGateway, http.StatusOK}, wantRequests: 2},
+		{name: "rate limited then accepted", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, retryAfter: "1", wantRequests: 2},
+		{name: "client error not retried", statuses: []int{http.StatusBadRequest}, wantRequests: 1, wantErr: true},
+		{name: "retries exhausted", statuses: []int{http.StatusInternalServerError}, wantRequests: 3, wantErr: true},
+		{
+			name:         "backoff given up with the context",
+			statuses:     []int{http.StatusServiceUnavailable},
+			retryAfter:   "30",
+			timeout:      100 * time.Millisecond,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp := r.Header.Get(WebhookTimestampHeader)
+				if got, want := r.Header.Get(WebhookSignatureHeader), "sha256="+SignWebhook("topsecret", timestamp, body); timestamp == "" || got != want {
+					t.Errorf("signature %q at %q, want %q", got, timestamp, want)
+				}
+				if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("headers %v", r.Header)
+				}
+				if !json.Valid(body) || !strings.Contains(string(body), `"camera_id":"door"`) {
+					t.Errorf("body %s", body)
+				}
+				mu.Lock()
+				status := tt.statuses[min(requests, len(tt.statuses)-1)]
+				requests++
+				mu.Unlock()
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			n := &WebhookNotifier{URL: server.URL + "/hooks/s3cret", Secret: "topsecret", Headers: map[string]string{"Authorization": "Bearer token"}, MaxRetries: 2}
+			err := n.Notify(ctx, event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() = %v, want error: %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "s3cret") {
+				t.Errorf("Notify() = %v, shows the URL", err)
+			}
+			if tt.timeout > 0 && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Notify() = %v, want the context deadline", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifierUnreachable(t *testing.T) {
+	event := &proto.DetectionEvent{CameraId: "door", Timestamp: time.Now().UnixMilli()}
+	// nothing listens on port 1
+	n := &WebhookNotifier{URL: "http://127.0.0.1:1/hooks/s3cret?token=s3cret", MaxRetries: -1}
+	err := n.Notify(context.Background(), event)
+	if err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("Notify() = %v, want an error without the URL", err)
+	}
+}
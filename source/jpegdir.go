@@ -0,0 +1,72 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gocv.io/x/gocv"
+)
+
+// JPEGDirSource replays the JPEG images of a directory in lexical order
+type JPEGDirSource struct {
+	dir   string
+	loop  bool
+	files []string
+	next  int
+}
+
+var _ FrameSource = (*JPEGDirSource)(nil)
+
+// NewJPEGDirSource creates a JPEGDirSource. When loop is true the replay
+// starts over after the last image, otherwise Read returns ErrNoFrame.
+func NewJPEGDirSource(dir string, loop bool) *JPEGDirSource {
+	return &JPEGDirSource{dir: dir, loop: loop}
+}
+
+func (s *JPEGDirSource) Open() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read frame directory: %w", err)
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if !e.IsDir() && (ext == ".jpg" || ext == ".jpeg") {
+			files = append(files, filepath.Join(s.dir, e.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no JPEG images found in %s", s.dir)
+	}
+	sort.Strings(files)
+	s.files = files
+	s.next = 0
+	return nil
+}
+
+func (s *JPEGDirSource) Read(dst *gocv.Mat) error {
+	if s.next >= len(s.files) {
+		if !s.loop || len(s.files) == 0 {
+			return ErrNoFrame
+		}
+		s.next = 0
+	}
+	name := s.files[s.next]
+	s.next++
+
+	img := gocv.IMRead(name, gocv.IMReadColor)
+	defer img.Close()
+	if img.Empty() {
+		return fmt.Errorf("failed to decode %s", name)
+	}
+	return img.CopyTo(dst)
+}
+
+func (s *JPEGDirSource) Close() error {
+	s.files = nil
+	s.next = 0
+	return nil
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"gocv.io/x/gocv"
+)
+
+// ErrNoFrame is returned by Read when the source has no frame to deliver
+var ErrNoFrame = errors.New("no frame available")
+
+// FrameSource produces the frames consumed by a CameraFeedActor.
+// Open is called before the first Read and may be called again after Close
+// to re-establish a lost connection.
+type FrameSource interface {
+	Open() error
+	Read(dst *gocv.Mat) error
+	Close() error
+}
+
+// New returns the FrameSource implementation matching the descriptor
+func New(d Descriptor) (FrameSource, error) {
+	switch d.Kind {
+	case KindDevice, KindRTSP, KindHTTP, KindFile:
+		return NewVideoCaptureSource(d), nil
+	case KindDirectory:
+		return NewJPEGDirSource(d.URL, true), nil
+	case KindTestPattern:
+		width, height, err := patternSize(d.URL)
+		if err != nil {
+			return nil, err
+		}
+		return NewTestPatternSource(width, height), nil
+	default:
+		return nil, fmt.Errorf("unsupported source kind %s", d.Kind)
+	}
+}
+
+func patternSize(raw string) (int, int, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return 0, 0, err
+	}
+	width, height := 640, 480
+	q := u.Query()
+	if v := q.Get("width"); v != "" {
+		if width, err = strconv.Atoi(v); err != nil || width <= 0 {
+			return 0, 0, fmt.Errorf("invalid test pattern width %q", v)
+		}
+	}
+	if v := q.Get("height"); v != "" {
+		if height, err = strconv.Atoi(v); err != nil || height <= 0 {
+			return 0, 0, fmt.Errorf("invalid test pattern height %q", v)
+		}
+	}
+	return width, height, nil
+}
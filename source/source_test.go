@@ -0,0 +1,131 @@
+package source
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gocv.io/x/gocv"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FrameSource
+		wantErr string
+	}{
+		{in: "0", want: &VideoCaptureSource{descriptor: Device(0)}},
+		{in: "rtsp://cam.local/stream", want: &VideoCaptureSource{descriptor: Descriptor{Kind: KindRTSP, URL: "rtsp://cam.local/stream"}}},
+		{in: "file:///var/videos/door.mp4", want: &VideoCaptureSource{descriptor: Descriptor{Kind: KindFile, URL: "/var/videos/door.mp4"}}},
+		{in: "dir:///var/frames", want: &JPEGDirSource{dir: "/var/frames", loop: true}},
+		{in: "test://", want: &TestPatternSource{width: 640, height: 480}},
+		{in: "test://?width=320", want: &TestPatternSource{width: 320, height: 480}},
+		{in: "test://?width=320&height=240", want: &TestPatternSource{width: 320, height: 240}},
+		{in: "test://?width=0", wantErr: `invalid test pattern width "0"`},
+		{in: "test://?height=tall", wantErr: `invalid test pattern height "tall"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := Parse(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := New(d)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("New() = %v, want error %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() = %v", err)
+			}
+			switch want := tt.want.(type) {
+			case *VideoCaptureSource:
+				if g, ok := got.(*VideoCaptureSource); !ok || g.descriptor != want.descriptor {
+					t.Errorf("New() = %#v, want %#v", got, want)
+				}
+			case *JPEGDirSource:
+				if g, ok := got.(*JPEGDirSource); !ok || g.dir != want.dir || g.loop != want.loop {
+					t.Errorf("New() = %#v, want %#v", got, want)
+				}
+			case *TestPatternSource:
+				if g, ok := got.(*TestPatternSource); !ok || g.width != want.width || g.height != want.height {
+					t.Errorf("New() = %#v, want %#v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJPEGDirSourceOpen(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"002.jpg", "001.JPEG", "003.jpeg", "notes.txt", "004.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "000.jpg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewJPEGDirSource(dir, false)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range s.files {
+		names = append(names, filepath.Base(f))
+	}
+	if want := []string{"001.JPEG", "002.jpg", "003.jpeg"}; !slices.Equal(names, want) {
+		t.Errorf("replays %v, want %v", names, want)
+	}
+
+	// without loop the replay ends after the last image
+	s.next = len(s.files)
+	dst := gocv.NewMat()
+	defer dst.Close()
+	if err := s.Read(&dst); !errors.Is(err, ErrNoFrame) {
+		t.Errorf("Read() after the last image = %v, want ErrNoFrame", err)
+	}
+	s.Close()
+	if err := s.Read(&dst); !errors.Is(err, ErrNoFrame) {
+		t.Errorf("Read() after Close = %v, want ErrNoFrame", err)
+	}
+}
+
+func TestJPEGDirSourceOpenErrors(t *testing.T) {
+	empty := t.TempDir()
+	if err := os.WriteFile(filepath.Join(empty, "notes.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, dir, wantErr string
+	}{
+		{name: "no JPEG", dir: empty, wantErr: "no JPEG images found"},
+		{name: "missing directory", dir: filepath.Join(empty, "missing"), wantErr: "failed to read frame directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewJPEGDirSource(tt.dir, true).Open(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Open() = %v, want an error with %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTestPatternSourceOpenErrors(t *testing.T) {
+	for _, size := range [][2]int{{0, 480}, {640, 0}, {-1, -1}} {
+		if err := NewTestPatternSource(size[0], size[1]).Open(); err == nil {
+			t.Errorf("Open() of %dx%d = nil, want an error", size[0], size[1])
+		}
+	}
+	dst := gocv.NewMat()
+	defer dst.Close()
+	if err := NewTestPatternSource(640, 480).Read(&dst); err == nil {
+		t.Error("Read() before Open = nil, want an error")
+	}
+}
@@ -0,0 +1,53 @@
+package source
+
+import (
+	"fmt"
+
+	"gocv.io/x/gocv"
+)
+
+// VideoCaptureSource reads frames through OpenCV's VideoCapture.
+// It handles local devices, RTSP/HTTP streams and video files.
+type VideoCaptureSource struct {
+	descriptor Descriptor
+	capture    *gocv.VideoCapture
+}
+
+var _ FrameSource = (*VideoCaptureSource)(nil)
+
+func NewVideoCaptureSource(d Descriptor) *VideoCaptureSource {
+	return &VideoCaptureSource{descriptor: d}
+}
+
+func (s *VideoCaptureSource) Open() error {
+	// the errors end up in the camera status, gocv's one repeats the URL
+	// with its credentials so it is left out
+	capture, err := gocv.OpenVideoCapture(s.descriptor.Target())
+	if err != nil || !capture.IsOpened() {
+		if capture != nil {
+			capture.Close()
+		}
+		return fmt.Errorf("failed to open %s", s.descriptor.Redacted())
+	}
+	s.capture = capture
+	return nil
+}
+
+func (s *VideoCaptureSource) Read(dst *gocv.Mat) error {
+	if s.capture == nil {
+		return fmt.Errorf("%s is not open", s.descriptor.Redacted())
+	}
+	if ok := s.capture.Read(dst); !ok || dst.Empty() {
+		return ErrNoFrame
+	}
+	return nil
+}
+
+func (s *VideoCaptureSource) Close() error {
+	if s.capture == nil {
+		return nil
+	}
+	err := s.capture.Close()
+	s.capture = nil
+	return err
+}
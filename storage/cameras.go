@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// CameraFile keeps the cameras in a JSON file, rewritten on every save
+type CameraFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewCameraFile(path string) *CameraFile {
+	return &CameraFile{path: path}
+}
+
+// LoadCameras returns the saved cameras, none when the file doesn't exist yet
+func (f *CameraFile) LoadCameras() ([]*proto.CameraSpec, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list proto.CameraSpecList
+	if err := protojson.Unmarshal(b, &list); err != nil {
+		return nil, err
+	}
+	return list.Cameras, nil
+}
+
+// SaveCameras replaces the saved cameras
+func (f *CameraFile) SaveCameras(cameras []*proto.CameraSpec) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := protojson.MarshalOptions{UseProtoNames: true, Multiline: true}.Marshal(&proto.CameraSpecList{Cameras: cameras})
+	if err != nil {
+		return err
+	}
+	return replaceFile(f.path, b)
+}
+
+// replaceFile writes b next to path and renames it, so a crash never leaves
+// path half written. The file is only readable by its owner, the camera
+// sources may hold credentials.
+func replaceFile(path string, b []byte) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of a temporary file left by a crash
+	if err := os.Chmod(tmp, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path"
 	"path/filepath"
 	"time"
 
@@ -11,15 +12,32 @@ import (
 )
 
 type FilesystemStorage struct {
-	logFile *os.File
+	logFile  *os.File
+	clipsDir string
 }
 
-func NewFilesystemStorage() (*FilesystemStorage, error) {
-	f, err := os.OpenFile("detections.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// NewFilesystemStorage stores the detections in dir/detections.log and the
+// clips in dir/clips
+func NewFilesystemStorage(dir string) (*FilesystemStorage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "detections.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, err
 	}
-	return &FilesystemStorage{logFile: f}, nil
+	return &FilesystemStorage{logFile: f, clipsDir: ClipsDir(dir)}, nil
+}
+
+// ClipName returns the path of a clip in the clips directory, which is also
+// its path under /clips/ in the web UI
+func ClipName(cameraID string, timestamp time.Time) string {
+	return path.Join(cameraID, timestamp.Format("20060102_150405.000")+".jpg")
+}
+
+// ClipsDir returns the directory of the clips of a FilesystemStorage in dir
+func ClipsDir(dir string) string {
+	return filepath.Join(dir, "clips")
 }
 
 func (fs *FilesystemStorage) SaveMetadata(ctx context.Context, cameraID string, timestamp time.Time, detections []*proto.Detection) error {
@@ -28,7 +46,20 @@ func (fs *FilesystemStorage) SaveMetadata(ctx context.Context, cameraID string,
 		"timestamp":  timestamp.UnixMilli(),
 		"detections": detections,
 	}
-	b, err := json.Marshal(meta)
+	return fs.writeLog(meta)
+}
+
+// SaveTrackEvent appends the start or end of a track to the detections log
+func (fs *FilesystemStorage) SaveTrackEvent(ctx context.Context, event *proto.TrackEvent) error {
+	return fs.writeLog(map[string]interface{}{
+		"camera_id":   event.CameraId,
+		"timestamp":   event.Timestamp,
+		"track_event": event,
+	})
+}
+
+func (fs *FilesystemStorage) writeLog(entry map[string]interface{}) error {
+	b, err := json.Marshal(entry)
 	if err != nil {
 		return err
 	}
@@ -43,9 +74,8 @@ func (fs *FilesystemStorage) SaveClip(ctx context.Context, cameraID string, time
 	if len(imageClip) == 0 {
 		return nil
 	}
-	clipDir := filepath.Join("clips", cameraID)
-	os.MkdirAll(clipDir, 0755)
-	imgName := filepath.Join(clipDir, timestamp.Format("20060102_150405.000")+".jpg")
+	imgName := filepath.Join(fs.clipsDir, ClipName(cameraID, timestamp))
+	os.MkdirAll(filepath.Dir(imgName), 0755)
 	return os.WriteFile(imgName, imageClip, 0644)
 }
 
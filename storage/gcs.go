@@ -59,6 +59,21 @@ func (g *GCSStorage) SaveMetadata(ctx context.Context, cameraID string, timestam
 	return w.Close()
 }
 
+func (g *GCSStorage) SaveTrackEvent(ctx context.Context, event *proto.TrackEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	objectPath := gcsObjectPath("tracks", event.CameraId, event.Timestamp, "json")
+	w := g.client.Bucket(g.bucketName).Object(objectPath).NewWriter(ctx)
+	w.ContentType = "application/json"
+	if _, err := w.Write(b); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
 func (g *GCSStorage) SaveClip(ctx context.Context, cameraID string, timestamp time.Time, imageClip []byte) error {
 	if len(imageClip) == 0 {
 		return nil
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// RuleFile keeps the alert rules in a JSON file, which can also be edited
+// by hand and reloaded
+type RuleFile struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewRuleFile(path string) *RuleFile {
+	return &RuleFile{path: path}
+}
+
+// LoadRules returns the saved rules, none when the file doesn't exist yet
+func (f *RuleFile) LoadRules() ([]*proto.AlertRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules proto.AlertRules
+	if err := protojson.Unmarshal(b, &rules); err != nil {
+		return nil, err
+	}
+	return rules.Rules, nil
+}
+
+// SaveRules replaces the saved rules
+func (f *RuleFile) SaveRules(rules []*proto.AlertRule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := protojson.MarshalOptions{UseProtoNames: true, Multiline: true}.Marshal(&proto.AlertRules{Rules: rules})
+	if err != nil {
+		return err
+	}
+	return replaceFile(f.path, b)
+}
@@ -0,0 +1,59 @@
+package tracking
+
+const (
+	processNoise     = 50.0 // acceleration noise, in pixels/s²
+	measurementNoise = 10.0 // detection jitter, in pixels
+)
+
+// kalman is a constant velocity Kalman filter on one axis.
+// Both axes of a track are independent so each gets its own 2 state filter.
+type kalman struct {
+	p, v float64       // position and velocity
+	cov  [2][2]float64 // covariance of (p, v)
+}
+
+func newKalman(p float64) *kalman {
+	return &kalman{
+		p: p,
+		cov: [2][2]float64{
+			{measurementNoise * measurementNoise, 0},
+			{0, 100 * 100}, // the initial velocity is unknown
+		},
+	}
+}
+
+// predict moves the state dt seconds forward
+func (k *kalman) predict(dt float64) {
+	if dt <= 0 {
+		return
+	}
+	k.p += k.v * dt
+
+	// cov = F cov Fᵀ + Q with F = [[1 dt] [0 1]]
+	c := k.cov
+	k.cov[0][0] = c[0][0] + dt*(c[0][1]+c[1][0]) + dt*dt*c[1][1]
+	k.cov[0][1] = c[0][1] + dt*c[1][1]
+	k.cov[1][0] = c[1][0] + dt*c[1][1]
+
+	q := processNoise * processNoise
+	k.cov[0][0] += q * dt * dt * dt / 3
+	k.cov[0][1] += q * dt * dt / 2
+	k.cov[1][0] += q * dt * dt / 2
+	k.cov[1][1] += q * dt
+}
+
+// update corrects the state with a measured position
+func (k *kalman) update(z float64) {
+	c := k.cov
+	s := c[0][0] + measurementNoise*measurementNoise
+	k0, k1 := c[0][0]/s, c[1][0]/s
+
+	y := z - k.p
+	k.p += k0 * y
+	k.v += k1 * y
+
+	k.cov[0][0] = (1 - k0) * c[0][0]
+	k.cov[0][1] = (1 - k0) * c[0][1]
+	k.cov[1][0] = c[1][0] - k1*c[0][0]
+	k.cov[1][1] = c[1][1] - k1*c[0][1]
+}
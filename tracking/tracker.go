@@ -0,0 +1,219 @@
+package tracking
+
+import (
+	"image"
+	"math"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/zaibon/surveilsense/detection"
+)
+
+// Config tunes how detections are associated to tracks
+type Config struct {
+	IoUThreshold float64 // smallest overlap for a detection to continue a track
+	MaxDistance  float64 // when boxes don't overlap enough, largest centroid move in pixels, 0 disables
+	MaxMissed    int     // frames a track survives without detection before it ends
+	Kalman       bool    // smooth and predict positions with a constant velocity Kalman filter
+}
+
+// DefaultConfig returns settings suited to a few frames per second
+func DefaultConfig() Config {
+	return Config{
+		IoUThreshold: 0.3,
+		MaxDistance:  50,
+		MaxMissed:    3,
+	}
+}
+
+// Track is an object followed across frames
+type Track struct {
+	ID        int64
+	Label     string
+	Box       image.Rectangle // last position, smoothed when the Kalman filter is on
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	missed int
+	x, y   *kalman
+}
+
+// Dwell is how long the object has been seen for
+func (t Track) Dwell() time.Duration {
+	return t.LastSeen.Sub(t.FirstSeen)
+}
+
+// Update is the outcome of feeding a frame's detections to a Tracker
+type Update struct {
+	Tracks  []Track // track of each detection, in the order of the results
+	Started []Track // tracks created by this frame
+	Ended   []Track // tracks not seen for more than MaxMissed frames
+}
+
+// lastID is the last ID given to a track by any Tracker. Trackers are
+// rebuilt when their camera changes settings or its processor restarts, the
+// IDs are shared so that they don't repeat. They start from the time the
+// process started, in microseconds, not to repeat across restarts either.
+var lastID atomic.Int64
+
+func init() {
+	lastID.Store(time.Now().UnixMicro())
+}
+
+// Tracker assigns stable IDs to the detections of a single camera.
+// It is not safe for concurrent use.
+type Tracker struct {
+	config Config
+	tracks []*Track
+	last   time.Time
+}
+
+func NewTracker(config Config) *Tracker {
+	return &Tracker{config: config}
+}
+
+// Update associates the detections of a frame taken at now to the current
+// tracks. Detections are only associated to tracks of the same label.
+func (t *Tracker) Update(results []detection.Result, now time.Time) Update {
+	var dt float64
+	if !t.last.IsZero() {
+		dt = now.Sub(t.last).Seconds()
+	}
+	t.last = now
+
+	predicted := make([]image.Rectangle, len(t.tracks))
+	for i, tr := range t.tracks {
+		predicted[i] = tr.predict(dt)
+	}
+
+	// candidate pairs, best overlap first then closest centroids
+	type pair struct {
+		track, result int
+		iou, distance float64
+	}
+	var pairs []pair
+	for i, tr := range t.tracks {
+		for j, res := range results {
+			if tr.Label != res.Label {
+				continue
+			}
+			p := pair{track: i, result: j, iou: iou(predicted[i], res.Box), distance: distance(predicted[i], res.Box)}
+			if p.iou >= t.config.IoUThreshold || (t.config.MaxDistance > 0 && p.distance <= t.config.MaxDistance) {
+				pairs = append(pairs, p)
+			}
+		}
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a].iou != pairs[b].iou {
+			return pairs[a].iou > pairs[b].iou
+		}
+		return pairs[a].distance < pairs[b].distance
+	})
+
+	var update Update
+	update.Tracks = make([]Track, len(results))
+	matchedTracks := make([]bool, len(t.tracks))
+	matchedResults := make([]bool, len(results))
+	for _, p := range pairs {
+		if matchedTracks[p.track] || matchedResults[p.result] {
+			continue
+		}
+		matchedTracks[p.track], matchedResults[p.result] = true, true
+		tr := t.tracks[p.track]
+		tr.correct(results[p.result].Box)
+		tr.LastSeen = now
+		tr.missed = 0
+		update.Tracks[p.result] = *tr
+	}
+
+	alive := t.tracks[:0]
+	for i, tr := range t.tracks {
+		if !matchedTracks[i] {
+			tr.missed++
+			if tr.missed > t.config.MaxMissed {
+				update.Ended = append(update.Ended, *tr)
+				continue
+			}
+		}
+		alive = append(alive, tr)
+	}
+	t.tracks = alive
+
+	for j, res := range results {
+		if matchedResults[j] {
+			continue
+		}
+		tr := &Track{ID: lastID.Add(1), Label: res.Label, Box: res.Box, FirstSeen: now, LastSeen: now}
+		if t.config.Kalman {
+			c := center(res.Box)
+			tr.x, tr.y = newKalman(c.X), newKalman(c.Y)
+		}
+		t.tracks = append(t.tracks, tr)
+		update.Tracks[j] = *tr
+		update.Started = append(update.Started, *tr)
+	}
+	return update
+}
+
+// Flush ends all the current tracks
+func (t *Tracker) Flush() []Track {
+	ended := make([]Track, 0, len(t.tracks))
+	for _, tr := range t.tracks {
+		ended = append(ended, *tr)
+	}
+	t.tracks = nil
+	return ended
+}
+
+// predict returns where the track is expected to be dt seconds after its last update
+func (tr *Track) predict(dt float64) image.Rectangle {
+	if tr.x == nil {
+		return tr.Box
+	}
+	tr.x.predict(dt)
+	tr.y.predict(dt)
+	return recenter(tr.Box, tr.x.p, tr.y.p)
+}
+
+// correct moves the track to the detected box
+func (tr *Track) correct(box image.Rectangle) {
+	if tr.x == nil {
+		tr.Box = box
+		return
+	}
+	c := center(box)
+	tr.x.update(c.X)
+	tr.y.update(c.Y)
+	tr.Box = recenter(box, tr.x.p, tr.y.p)
+}
+
+type point struct{ X, Y float64 }
+
+func center(r image.Rectangle) point {
+	return point{
+		X: float64(r.Min.X+r.Max.X) / 2,
+		Y: float64(r.Min.Y+r.Max.Y) / 2,
+	}
+}
+
+// recenter moves r so that its center is at (x, y)
+func recenter(r image.Rectangle, x, y float64) image.Rectangle {
+	topLeft := image.Pt(int(math.Round(x-float64(r.Dx())/2)), int(math.Round(y-float64(r.Dy())/2)))
+	return image.Rectangle{Min: topLeft, Max: topLeft.Add(r.Size())}
+}
+
+func iou(a, b image.Rectangle) float64 {
+	inter := a.Intersect(b)
+	if inter.Empty() {
+		return 0
+	}
+	i := float64(inter.Dx() * inter.Dy())
+	u := float64(a.Dx()*a.Dy()+b.Dx()*b.Dy()) - i
+	return i / u
+}
+
+func distance(a, b image.Rectangle) float64 {
+	ca, cb := center(a), center(b)
+	return math.Hypot(ca.X-cb.X, ca.Y-cb.Y)
+}
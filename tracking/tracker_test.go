@@ -0,0 +1,185 @@
+package tracking
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/zaibon/surveilsense/detection"
+)
+
+func box(x, y int) image.Rectangle {
+	return image.Rect(x, y, x+100, y+100)
+}
+
+func face(x, y int) detection.Result {
+	return detection.Result{Label: "face", Confidence: 1, Box: box(x, y)}
+}
+
+func TestTrackerAssociation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		first  []detection.Result
+		second []detection.Result
+		// index in first of the track continued by each result of second, -1 for a new track
+		want []int
+	}{
+		{
+			name:   "overlapping box continues the track",
+			config: DefaultConfig(),
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{face(10, 10)},
+			want:   []int{0},
+		},
+		{
+			name:   "close box continues the track without overlap",
+			config: Config{IoUThreshold: 0.3, MaxDistance: 150},
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{face(120, 0)},
+			want:   []int{0},
+		},
+		{
+			name:   "far box starts a new track",
+			config: DefaultConfig(),
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{face(500, 500)},
+			want:   []int{-1},
+		},
+		{
+			name:   "distance disabled",
+			config: Config{IoUThreshold: 0.3},
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{face(60, 0)},
+			want:   []int{-1},
+		},
+		{
+			name:   "other label starts a new track",
+			config: DefaultConfig(),
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{{Label: "person", Box: box(0, 0)}},
+			want:   []int{-1},
+		},
+		{
+			name:   "best overlap wins",
+			config: DefaultConfig(),
+			first:  []detection.Result{face(0, 0), face(300, 0)},
+			second: []detection.Result{face(290, 5), face(5, 0)},
+			want:   []int{1, 0},
+		},
+		{
+			name:   "a track continues with one result only",
+			config: DefaultConfig(),
+			first:  []detection.Result{face(0, 0)},
+			second: []detection.Result{face(5, 0), face(0, 40)},
+			want:   []int{0, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker(tt.config)
+			now := time.Now()
+			first := tracker.Update(tt.first, now)
+			second := tracker.Update(tt.second, now.Add(time.Second))
+			if len(second.Tracks) != len(tt.second) {
+				t.Fatalf("got %d tracks, want %d", len(second.Tracks), len(tt.second))
+			}
+			started := 0
+			for i, want := range tt.want {
+				got := second.Tracks[i]
+				if want < 0 {
+					started++
+					for _, tr := range first.Tracks {
+						if got.ID == tr.ID {
+							t.Errorf("result %d continues track %d, want a new track", i, tr.ID)
+						}
+					}
+					continue
+				}
+				if got.ID != first.Tracks[want].ID {
+					t.Errorf("result %d has track %d, want %d", i, got.ID, first.Tracks[want].ID)
+				}
+				if !got.FirstSeen.Equal(now) || got.Dwell() != time.Second {
+					t.Errorf("result %d: first seen %v and dwell %v, want %v and 1s", i, got.FirstSeen, got.Dwell(), now)
+				}
+			}
+			if len(second.Started) != started {
+				t.Errorf("got %d started tracks, want %d", len(second.Started), started)
+			}
+		})
+	}
+}
+
+func TestTrackerAging(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxMissed int
+		missed    int // frames without the object
+		wantEnded bool
+	}{
+		{name: "no miss allowed", maxMissed: 0, missed: 1, wantEnded: true},
+		{name: "within max missed", maxMissed: 3, missed: 3, wantEnded: false},
+		{name: "beyond max missed", maxMissed: 3, missed: 4, wantEnded: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker(Config{IoUThreshold: 0.3, MaxMissed: tt.maxMissed})
+			now := time.Now()
+			id := tracker.Update([]detection.Result{face(0, 0)}, now).Tracks[0].ID
+
+			var ended []Track
+			for i := 1; i <= tt.missed; i++ {
+				update := tracker.Update(nil, now.Add(time.Duration(i)*time.Second))
+				if len(update.Ended) > 0 && i < tt.missed {
+					t.Fatalf("track ended after %d missed frames", i)
+				}
+				ended = append(ended, update.Ended...)
+			}
+			if tt.wantEnded != (len(ended) == 1 && ended[0].ID == id) {
+				t.Fatalf("got ended tracks %v, want track %d ended: %v", ended, id, tt.wantEnded)
+			}
+
+			// the object coming back continues its track unless it ended
+			again := tracker.Update([]detection.Result{face(0, 0)}, now.Add(time.Minute)).Tracks[0]
+			if (again.ID == id) == tt.wantEnded {
+				t.Errorf("object back with track %d, first track %d ended: %v", again.ID, id, tt.wantEnded)
+			}
+		})
+	}
+}
+
+func TestTrackerIDs(t *testing.T) {
+	now := time.Now()
+	seen := make(map[int64]bool)
+	var last int64
+	// trackers are rebuilt when a camera changes settings, IDs must not repeat
+	for range 3 {
+		tracker := NewTracker(DefaultConfig())
+		update := tracker.Update([]detection.Result{face(0, 0), face(500, 0)}, now)
+		for _, tr := range update.Tracks {
+			if tr.ID <= last {
+				t.Errorf("track ID %d after %d, want increasing IDs", tr.ID, last)
+			}
+			if seen[tr.ID] {
+				t.Errorf("track ID %d given twice", tr.ID)
+			}
+			seen[tr.ID] = true
+			last = tr.ID
+		}
+	}
+	if start := time.UnixMicro(last); now.Sub(start) > time.Hour || start.After(now) {
+		t.Errorf("track ID %d doesn't count from the process start time", last)
+	}
+}
+
+func TestTrackerFlush(t *testing.T) {
+	tracker := NewTracker(DefaultConfig())
+	now := time.Now()
+	tracker.Update([]detection.Result{face(0, 0), face(500, 0)}, now)
+	if ended := tracker.Flush(); len(ended) != 2 {
+		t.Fatalf("got %d tracks flushed, want 2", len(ended))
+	}
+	if update := tracker.Update([]detection.Result{face(0, 0)}, now); len(update.Started) != 1 {
+		t.Errorf("got %d tracks started after flush, want 1", len(update.Started))
+	}
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/tochemey/goakt/v3/actor"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// tripwire is the JSON form of a proto.Tripwire, points are [x, y] pairs in [0, 1]
+type tripwire struct {
+	Name string     `json:"name"`
+	A    [2]float32 `json:"a"`
+	B    [2]float32 `json:"b"`
+}
+
+// WithAnalytics serves the counts of the AnalyticsActor
+func (s *Server) WithAnalytics(pid *actor.PID) *Server {
+	s.analyticsPID = pid
+	return s
+}
+
+func (s *Server) tripwiresHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	status, err := s.cameraStatus(r.Context(), cam)
+	if err != nil {
+		log.Printf("Failed to get status of camera %s: %v", cam.CameraID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tripwires := []tripwire{}
+	for _, t := range status.GetProcessing().GetTripwires() {
+		tripwires = append(tripwires, tripwire{
+			Name: t.Name,
+			A:    [2]float32{t.A.GetX(), t.A.GetY()},
+			B:    [2]float32{t.B.GetX(), t.B.GetY()},
+		})
+	}
+	writeJSON(w, tripwires)
+}
+
+// updateTripwiresHandler replaces the tripwires of a camera with the JSON list in the body
+func (s *Server) updateTripwiresHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	var body []tripwire
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid tripwires: %v", err), http.StatusBadRequest)
+		return
+	}
+	tripwires := make([]*proto.Tripwire, 0, len(body))
+	for _, t := range body {
+		tripwires = append(tripwires, &proto.Tripwire{
+			Name: t.Name,
+			A:    &proto.Point{X: t.A[0], Y: t.A[1]},
+			B:    &proto.Point{X: t.B[0], Y: t.B[1]},
+		})
+	}
+	if err := actors.ValidateTripwires(tripwires); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.updateProcessing(w, r, cam, func(config *proto.ProcessingConfig) {
+		config.Tripwires = tripwires
+	})
+}
+
+// analyticsHandler returns the tripwire and zone counts of a camera. The query
+// parameters from and to (RFC 3339) default to the last 24 hours, bucket
+// (a duration in whole minutes, e.g. 15m) defaults to an hour.
+func (s *Server) analyticsHandler(w http.ResponseWriter, r *http.Request) {
+	cameraID := r.PathValue("id")
+	if s.analyticsPID == nil {
+		http.Error(w, "analytics are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	to := time.Now()
+	if v := query.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid to %q, expected RFC 3339", v), http.StatusBadRequest)
+			return
+		}
+		to = t
+	}
+	from := to.Add(-24 * time.Hour)
+	if v := query.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid from %q, expected RFC 3339", v), http.StatusBadRequest)
+			return
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+	bucket := actors.DefaultAnalyticsBucket
+	if v := query.Get("bucket"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Minute || d%time.Minute != 0 {
+			http.Error(w, fmt.Sprintf("invalid bucket %q, expected whole minutes such as 15m or 1h", v), http.StatusBadRequest)
+			return
+		}
+		bucket = d
+	}
+
+	resp, err := actor.Ask(r.Context(), s.analyticsPID, &proto.GetAnalyticsCounts{
+		CameraId:      cameraID,
+		From:          from.UnixMilli(),
+		To:            to.UnixMilli(),
+		BucketSeconds: int64(bucket.Seconds()),
+	}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get analytics of camera %s: %v", cameraID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	counts, ok := resp.(*proto.AnalyticsCounts)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	writeProto(w, counts)
+}
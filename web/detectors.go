@@ -0,0 +1,133 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/zaibon/surveilsense/detection"
+)
+
+// detectorInfo describes a registered detector, Params is only set for tunable ones
+type detectorInfo struct {
+	Name   string                   `json:"name"`
+	Params *detection.CascadeParams `json:"params,omitempty"`
+}
+
+func (s *Server) detectorInfo(name string) (detectorInfo, bool) {
+	d, ok := s.detectors.Get(name)
+	if !ok {
+		return detectorInfo{}, false
+	}
+	info := detectorInfo{Name: name}
+	if cascade, ok := d.(*detection.CascadeDetector); ok {
+		params := cascade.Params()
+		info.Params = &params
+	}
+	return info, true
+}
+
+func (s *Server) detectorsHandler(w http.ResponseWriter, r *http.Request) {
+	detectors := []detectorInfo{}
+	for _, name := range s.detectors.Names() {
+		if info, ok := s.detectorInfo(name); ok {
+			detectors = append(detectors, info)
+		}
+	}
+	writeJSON(w, detectors)
+}
+
+func (s *Server) detectorHandler(w http.ResponseWriter, r *http.Request) {
+	info, ok := s.detectorInfo(r.PathValue("name"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, info)
+}
+
+func (s *Server) detectorParamsHandler(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.detectors.Get(r.PathValue("name"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	cascade, ok := d.(*detection.CascadeDetector)
+	if !ok {
+		http.Error(w, fmt.Sprintf("detector %s has no tunable parameters", r.PathValue("name")), http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params, err := parseCascadeParams(r, cascade.Params())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := cascade.SetParams(params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	info, _ := s.detectorInfo(r.PathValue("name"))
+	writeJSON(w, info)
+}
+
+// parseCascadeParams reads the cascade settings from the form values
+// scale_factor, min_neighbors, min_size and max_size (WIDTHxHEIGHT, 0x0 for
+// no limit), grayscale and equalize_hist. Missing values are taken from base.
+func parseCascadeParams(r *http.Request, base detection.CascadeParams) (detection.CascadeParams, error) {
+	params := base
+	if v := r.FormValue("scale_factor"); v != "" {
+		scale, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return params, fmt.Errorf("invalid scale_factor %q", v)
+		}
+		params.ScaleFactor = scale
+	}
+	if v := r.FormValue("min_neighbors"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return params, fmt.Errorf("invalid min_neighbors %q", v)
+		}
+		params.MinNeighbors = n
+	}
+	if v := r.FormValue("min_size"); v != "" {
+		size, err := detection.ParseSize(v)
+		if err != nil {
+			return params, fmt.Errorf("min_size: %w", err)
+		}
+		params.MinSize = size
+	}
+	if v := r.FormValue("max_size"); v != "" {
+		size, err := detection.ParseSize(v)
+		if err != nil {
+			return params, fmt.Errorf("max_size: %w", err)
+		}
+		params.MaxSize = size
+	}
+	if v := r.FormValue("grayscale"); v != "" {
+		gray, err := parseFormBool(v)
+		if err != nil {
+			return params, fmt.Errorf("invalid grayscale %q", v)
+		}
+		params.Grayscale = gray
+	}
+	if v := r.FormValue("equalize_hist"); v != "" {
+		equalize, err := parseFormBool(v)
+		if err != nil {
+			return params, fmt.Errorf("invalid equalize_hist %q", v)
+		}
+		params.EqualizeHist = equalize
+	}
+	return params, params.Validate()
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,169 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/tochemey/goakt/v3/actor"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// rule is the JSON form of a proto.AlertRule
+type rule struct {
+	ID            string       `json:"id"`
+	Cameras       []string     `json:"cameras"`
+	Labels        []string     `json:"labels"`
+	MinConfidence float32      `json:"min_confidence"`
+	Zones         []string     `json:"zones"`
+	Schedule      []timeWindow `json:"schedule"`
+	MinCount      int32        `json:"min_count"` // matching detections in one event, not over time, 1 when 0
+	Notifiers     []string     `json:"notifiers"`
+	Disabled      bool         `json:"disabled"`
+}
+
+// timeWindow is the JSON form of a proto.TimeWindow. An end equal to the
+// start, e.g. 00:00 to 00:00, spans the whole day.
+type timeWindow struct {
+	Days  []string `json:"days"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// WithNotifications manages the alert rules of the NotificationActor
+func (s *Server) WithNotifications(pid *actor.PID) *Server {
+	s.notificationPID = pid
+	return s
+}
+
+func ruleFromProto(r *proto.AlertRule) rule {
+	schedule := []timeWindow{}
+	for _, w := range r.Schedule {
+		schedule = append(schedule, timeWindow{Days: append([]string{}, w.Days...), Start: w.Start, End: w.End})
+	}
+	return rule{
+		ID:            r.Id,
+		Cameras:       append([]string{}, r.CameraIds...),
+		Labels:        append([]string{}, r.Labels...),
+		MinConfidence: r.MinConfidence,
+		Zones:         append([]string{}, r.Zones...),
+		Schedule:      schedule,
+		MinCount:      r.MinCount,
+		Notifiers:     append([]string{}, r.Notifiers...),
+		Disabled:      r.Disabled,
+	}
+}
+
+func ruleToProto(r rule) (*proto.AlertRule, error) {
+	alert := &proto.AlertRule{
+		Id:            r.ID,
+		CameraIds:     r.Cameras,
+		Labels:        r.Labels,
+		MinConfidence: r.MinConfidence,
+		Zones:         r.Zones,
+		MinCount:      r.MinCount,
+		Notifiers:     r.Notifiers,
+		Disabled:      r.Disabled,
+	}
+	for _, w := range r.Schedule {
+		alert.Schedule = append(alert.Schedule, &proto.TimeWindow{Days: w.Days, Start: w.Start, End: w.End})
+	}
+	if err := actors.ValidateAlertRule(alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (s *Server) rulesHandler(w http.ResponseWriter, r *http.Request) {
+	if s.notificationPID == nil {
+		http.Error(w, "alert rules are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	resp, err := actor.Ask(r.Context(), s.notificationPID, &proto.GetAlertRules{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get alert rules: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rules, ok := resp.(*proto.AlertRules)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	list := []rule{}
+	for _, r := range rules.Rules {
+		list = append(list, ruleFromProto(r))
+	}
+	writeJSON(w, list)
+}
+
+// putRuleHandler adds the rule in the JSON body, or replaces the one with the same id
+func (s *Server) putRuleHandler(w http.ResponseWriter, r *http.Request) {
+	var body rule
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid rule: %v", err), http.StatusBadRequest)
+		return
+	}
+	alert, err := ruleToProto(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.askRules(w, r, &proto.PutAlertRule{Rule: alert})
+}
+
+// setRulesHandler replaces all the rules with the JSON list in the body
+func (s *Server) setRulesHandler(w http.ResponseWriter, r *http.Request) {
+	var body []rule
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid rules: %v", err), http.StatusBadRequest)
+		return
+	}
+	msg := &proto.SetAlertRules{}
+	for _, r := range body {
+		alert, err := ruleToProto(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg.Rules = append(msg.Rules, alert)
+	}
+	s.askRules(w, r, msg)
+}
+
+func (s *Server) deleteRuleHandler(w http.ResponseWriter, r *http.Request) {
+	s.askRules(w, r, &proto.DeleteAlertRule{Id: r.PathValue("id")})
+}
+
+// reloadRulesHandler loads the rules file again, after editing it by hand
+func (s *Server) reloadRulesHandler(w http.ResponseWriter, r *http.Request) {
+	s.askRules(w, r, &proto.ReloadAlertRules{})
+}
+
+// askRules sends a rule command to the NotificationActor and replies with no content once applied
+func (s *Server) askRules(w http.ResponseWriter, r *http.Request, msg protobuf.Message) {
+	if s.notificationPID == nil {
+		http.Error(w, "alert rules are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	resp, err := actor.Ask(r.Context(), s.notificationPID, msg, askTimeout)
+	if err != nil {
+		log.Printf("Failed to update alert rules: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result, ok := resp.(*proto.CommandResult)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	if !result.Ok {
+		http.Error(w, result.Error, http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,61 +2,133 @@ package web
 
 import (
 	"context"
+	"embed"
+	"fmt"
 	"html/template"
 	"io/fs"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tochemey/goakt/v3/actor"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+
 	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/detection"
+	"github.com/zaibon/surveilsense/live"
+	"github.com/zaibon/surveilsense/proto"
+	"github.com/zaibon/surveilsense/source"
 )
 
+const askTimeout = 2 * time.Second
+
+// the templates are embedded so that the server runs from any directory
+//
+//go:embed *.tmpl
+var templates embed.FS
+
 var (
-	clipsListTmpl  = template.Must(template.ParseFiles("web/clips-list.tmpl"))
-	indexTmpl      = template.Must(template.ParseFiles("web/index.tmpl"))
-	clipsTmpl      = template.Must(template.ParseFiles("web/clips.tmpl"))
-	cameraListTmpl = template.Must(template.ParseFiles("web/camera-list.tmpl"))
+	clipsListTmpl  = template.Must(template.ParseFS(templates, "clips-list.tmpl"))
+	indexTmpl      = template.Must(template.ParseFS(templates, "index.tmpl"))
+	clipsTmpl      = template.Must(template.ParseFS(templates, "clips.tmpl"))
+	cameraListTmpl = template.Must(template.ParseFS(templates, "camera-list.tmpl"))
+	processorsTmpl = template.Must(template.ParseFS(templates, "processor-list.tmpl"))
+	framesTmpl     = template.Must(template.ParseFS(templates, "camera-frames.tmpl"))
+	zonesTmpl      = template.Must(template.ParseFS(templates, "zones.tmpl"))
 )
 
 type Camera struct {
-	CameraID string     `json:"camera_id"`
-	DeviceID int        `json:"device_id"`
-	PID      *actor.PID `json:"-"`
+	CameraID string            `json:"camera_id"`
+	Source   string            `json:"source"`
+	State    string            `json:"state,omitempty"`
+	Dropped  int64             `json:"frames_dropped,omitempty"`
+	Restarts int32             `json:"restarts,omitempty"`
+	Failure  string            `json:"last_failure,omitempty"`
+	FailedAt time.Time         `json:"last_failure_at,omitempty"`
+	Spec     *proto.CameraSpec `json:"-"`
+	PID      *actor.PID        `json:"-"`
 }
 
 type Server struct {
-	mux          *http.ServeMux
-	actorSystem  actor.ActorSystem
-	frameProcPID *actor.PID
-	cameras      map[string]Camera // Track CameraFeedActor PIDs
+	mux             *http.ServeMux
+	coordinatorPID  *actor.PID // owns the cameras
+	frameProcPID    *actor.PID
+	hub             *live.Hub
+	detectors       *detection.Registry
+	analyticsPID    *actor.PID
+	busPID          *actor.PID
+	notificationPID *actor.PID
+	clipsDir        string // empty when the clips aren't stored locally
 }
 
-func NewServer(actorSystem actor.ActorSystem, frameProcPID *actor.PID) *Server {
+func NewServer(coordinatorPID *actor.PID, frameProcPID *actor.PID, hub *live.Hub, detectors *detection.Registry) *Server {
 	mux := http.NewServeMux()
-	server := &Server{mux: mux, actorSystem: actorSystem, frameProcPID: frameProcPID, cameras: make(map[string]Camera)}
+	server := &Server{mux: mux, coordinatorPID: coordinatorPID, frameProcPID: frameProcPID, hub: hub, detectors: detectors, clipsDir: "clips"}
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		indexTmpl.ExecuteTemplate(w, "index", nil)
+		indexTmpl.ExecuteTemplate(w, "index", map[string]any{
+			"Detectors":       detectors.Names(),
+			"DefaultDetector": actors.DefaultDetector,
+		})
 	})
 	mux.HandleFunc("/clips", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		clipsTmpl.ExecuteTemplate(w, "clips", nil)
 	})
-	mux.Handle("/clips/", http.StripPrefix("/clips/", http.FileServer(http.Dir("clips"))))
+	mux.HandleFunc("/clips/", server.clipHandler)
 
 	mux.HandleFunc("/api/cameras", server.camerasHandler)
 	mux.HandleFunc("/api/cameras/", server.cameraHandler)
-	mux.HandleFunc("/api/clips", clipsHandler)
+	mux.HandleFunc("PUT /api/cameras/{id}", server.updateCameraHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/status", server.cameraStatusHandler)
+	mux.HandleFunc("POST /api/cameras/{id}/pause", server.cameraCommandHandler(func() protobuf.Message { return &proto.PauseCamera{} }))
+	mux.HandleFunc("POST /api/cameras/{id}/resume", server.cameraCommandHandler(func() protobuf.Message { return &proto.ResumeCamera{} }))
+	mux.HandleFunc("PUT /api/cameras/{id}/config", server.cameraConfigHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/snapshot", server.cameraSnapshotHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/snapshot.jpg", server.liveSnapshotHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/stream", server.streamHandler)
+	mux.HandleFunc("GET /api/cameras/frames", server.framesHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/zones", server.zonesHandler)
+	mux.HandleFunc("PUT /api/cameras/{id}/zones", server.updateZonesHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/tripwires", server.tripwiresHandler)
+	mux.HandleFunc("PUT /api/cameras/{id}/tripwires", server.updateTripwiresHandler)
+	mux.HandleFunc("GET /api/cameras/{id}/analytics", server.analyticsHandler)
+	mux.HandleFunc("GET /cameras/{id}/zones", server.zonesPageHandler)
+	mux.HandleFunc("GET /api/processors", server.processorsHandler)
+	mux.HandleFunc("GET /api/processors/list", server.processorListHandler)
+	mux.HandleFunc("GET /api/detectors", server.detectorsHandler)
+	mux.HandleFunc("GET /api/detectors/{name}", server.detectorHandler)
+	mux.HandleFunc("PUT /api/detectors/{name}/params", server.detectorParamsHandler)
+	mux.HandleFunc("GET /api/subscriptions", server.subscriptionsHandler)
+	mux.HandleFunc("POST /api/subscriptions", server.subscribeHandler)
+	mux.HandleFunc("DELETE /api/subscriptions/{id}", server.unsubscribeHandler)
+	mux.HandleFunc("GET /api/rules", server.rulesHandler)
+	mux.HandleFunc("POST /api/rules", server.putRuleHandler)
+	mux.HandleFunc("PUT /api/rules", server.setRulesHandler)
+	mux.HandleFunc("DELETE /api/rules/{id}", server.deleteRuleHandler)
+	mux.HandleFunc("POST /api/rules/reload", server.reloadRulesHandler)
+	mux.HandleFunc("/api/clips", server.clipsHandler)
 
 	return server
 }
 
-func (s *Server) Start() {
-	log.Println("Starting HTTP server on :8080")
-	if err := http.ListenAndServe(":8080", s.mux); err != nil {
+// WithClipsDir serves the clips stored in dir, none when dir is empty
+func (s *Server) WithClipsDir(dir string) *Server {
+	s.clipsDir = dir
+	return s
+}
+
+// Start serves the web UI and the API on addr, e.g. :8080
+func (s *Server) Start(addr string) {
+	log.Printf("Starting HTTP server on %s", addr)
+	if err := http.ListenAndServe(addr, s.mux); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
@@ -64,68 +136,669 @@ func (s *Server) Start() {
 func (s *Server) camerasHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		list := make([]Camera, 0, len(s.cameras))
-		for _, cam := range s.cameras {
-			list = append(list, cam)
-		}
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		cameraListTmpl.ExecuteTemplate(w, "camera-list", list)
+		cameraListTmpl.ExecuteTemplate(w, "camera-list", s.cameraList(r.Context()))
 	case http.MethodPost:
-		var cam Camera
 		if err := r.ParseForm(); err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		cam.CameraID = r.FormValue("camera_id")
-		cam.DeviceID = 0
-		if v := r.FormValue("device_id"); v != "" {
-			id, err := strconv.Atoi(v)
-			if err == nil {
-				cam.DeviceID = id
-			}
+		// device_id is still accepted for clients predating source descriptors
+		raw := r.FormValue("source")
+		if raw == "" {
+			raw = r.FormValue("device_id")
+		}
+		if raw == "" {
+			raw = "0"
+		}
+		config, err := parseCaptureConfig(r, actors.DefaultCaptureConfig())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		processing, err := s.parseProcessingConfig(r, actors.DefaultProcessingConfig())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
-		pid, err := s.actorSystem.Spawn(r.Context(), cam.CameraID, actors.NewCameraFeedActorWithConfig(cam.CameraID, cam.DeviceID, s.frameProcPID))
+		result, err := s.askCoordinator(r.Context(), &proto.AddCamera{Camera: &proto.CameraSpec{
+			CameraId:   r.FormValue("camera_id"),
+			Source:     raw,
+			Capture:    config,
+			Processing: processing,
+		}})
 		if err != nil {
-			log.Printf("Failed to spawn CameraFeedActor: %v", err)
+			log.Printf("Failed to add camera: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		cam.PID = pid
-		s.cameras[cam.CameraID] = cam
-		// Return updated camera list HTML
-		list := make([]Camera, 0, len(s.cameras))
-		for _, cam := range s.cameras {
-			list = append(list, cam)
+		if !result.Ok {
+			http.Error(w, result.Error, http.StatusBadRequest)
+			return
 		}
+		// Return updated camera list HTML
+		w.Header().Set("HX-Trigger", "cameras-changed")
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		cameraListTmpl.ExecuteTemplate(w, "camera-list", list)
+		cameraListTmpl.ExecuteTemplate(w, "camera-list", s.cameraList(r.Context()))
 	}
 }
 
 func (s *Server) cameraHandler(w http.ResponseWriter, r *http.Request) {
 	id := filepath.Base(r.URL.Path)
 	if r.Method == http.MethodDelete {
-		// Stop the actor for this camera if it exists
-		camera, ok := s.cameras[id]
-		if ok && camera.PID != nil {
-			err := camera.PID.Shutdown(context.Background())
-			if err != nil {
-				log.Printf("Failed to stop CameraFeedActor %s: %v", id, err)
-			}
-			delete(s.cameras, id)
+		// removing an unknown camera is not an error, it is gone either way
+		if _, err := s.askCoordinator(r.Context(), &proto.RemoveCamera{CameraId: id}); err != nil {
+			log.Printf("Failed to remove camera %s: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
+		w.Header().Set("HX-Trigger", "cameras-changed")
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
 
+func (s *Server) cameraStatusHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	s.writeCameraStatus(w, r, cam)
+}
+
+// cameraCommandHandler sends the message built by newMsg to the camera and
+// replies with the camera status once the command is applied
+func (s *Server) cameraCommandHandler(newMsg func() protobuf.Message) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cam, ok := s.camera(w, r)
+		if !ok {
+			return
+		}
+		s.sendCameraCommand(w, r, cam, newMsg())
+	}
+}
+
+func (s *Server) cameraConfigHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// fields missing from the form keep their current value
+	capture, err := parseCaptureConfig(r, cam.Spec.GetCapture())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	processing, err := s.parseProcessingConfig(r, cam.Spec.GetProcessing())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.updateCamera(w, r, &proto.CameraSpec{CameraId: cam.CameraID, Capture: capture, Processing: processing})
+}
+
+// updateCameraHandler edits a camera with the form values of POST
+// /api/cameras, source included. A new source restarts the camera.
+func (s *Server) updateCameraHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	resp, err := actor.Ask(r.Context(), s.coordinatorPID, &proto.GetCamera{CameraId: id}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get camera %s: %v", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// a stopped camera can be edited too, the update starts it again
+	info, ok := resp.(*proto.CameraInfo)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	capture, err := parseCaptureConfig(r, info.Spec.GetCapture())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	processing, err := s.parseProcessingConfig(r, info.Spec.GetProcessing())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.updateCamera(w, r, &proto.CameraSpec{CameraId: id, Source: r.FormValue("source"), Capture: capture, Processing: processing})
+}
+
+// updateCamera sends the new settings of a camera to the coordinator, which
+// saves them, and replies with the camera status
+func (s *Server) updateCamera(w http.ResponseWriter, r *http.Request, spec *proto.CameraSpec) {
+	result, err := s.askCoordinator(r.Context(), &proto.UpdateCamera{Camera: spec})
+	if err != nil {
+		log.Printf("Failed to update camera %s: %v", spec.CameraId, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !result.Ok {
+		http.Error(w, result.Error, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("HX-Trigger", "cameras-changed")
+	// the camera is a new actor when the update restarted it
+	pid, err := s.coordinatorPID.Child(spec.CameraId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeCameraStatus(w, r, Camera{CameraID: spec.CameraId, PID: pid})
+}
+
+func (s *Server) cameraSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	resp, err := actor.Ask(r.Context(), cam.PID, &proto.TakeSnapshot{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to take snapshot of camera %s: %v", cam.CameraID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	snapshot, ok := resp.(*proto.Snapshot)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	if len(snapshot.ImageData) == 0 {
+		http.Error(w, "no frame captured yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(snapshot.ImageData)
+}
+
+// processorsHandler returns the queue depth of each frame processor
+func (s *Server) processorsHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := actor.Ask(r.Context(), s.frameProcPID, &proto.GetPoolStatus{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get frame processors status: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	status, ok := resp.(*proto.PoolStatus)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	writeProto(w, status)
+}
+
+// processorListHandler renders the frame processors with their restarts for the dashboard
+func (s *Server) processorListHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := actor.Ask(r.Context(), s.frameProcPID, &proto.GetPoolStatus{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get frame processors status: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	status, ok := resp.(*proto.PoolStatus)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	type processor struct {
+		*proto.ProcessorStatus
+		FailedAt time.Time
+	}
+	list := make([]processor, 0, len(status.Processors))
+	for _, p := range status.Processors {
+		view := processor{ProcessorStatus: p}
+		if p.LastFailureAt != 0 {
+			view.FailedAt = time.UnixMilli(p.LastFailureAt)
+		}
+		list = append(list, view)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	processorsTmpl.ExecuteTemplate(w, "processor-list", list)
+}
+
+// liveView returns the view requested with the overlay query parameter
+func liveView(r *http.Request) live.View {
+	if overlay, _ := strconv.ParseBool(r.URL.Query().Get("overlay")); overlay {
+		return live.Overlay
+	}
+	return live.Raw
+}
+
+func (s *Server) liveSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	id := cam.CameraID
+	frame, ok := s.hub.Latest(id, liveView(r))
+	if !ok {
+		http.Error(w, "no frame captured yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Last-Modified", frame.Timestamp.UTC().Format(http.TimeFormat))
+	w.Write(frame.Data)
+}
+
+// streamHandler serves the camera feed as an MJPEG stream
+func (s *Server) streamHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	id := cam.CameraID
+	view := liveView(r)
+	frames, unsubscribe := s.hub.Subscribe(id, view)
+	defer unsubscribe()
+
+	mw := multipart.NewWriter(w)
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mw.Boundary())
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Connection", "close")
+	rc := http.NewResponseController(w)
+
+	writeFrame := func(frame live.Frame) error {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":   {"image/jpeg"},
+			"Content-Length": {strconv.Itoa(len(frame.Data))},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(frame.Data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	// start with the latest frame so the viewer doesn't wait for the next capture
+	if frame, ok := s.hub.Latest(id, view); ok {
+		if err := writeFrame(frame); err != nil {
+			return
+		}
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case frame, ok := <-frames:
+			if !ok {
+				// camera removed
+				return
+			}
+			if err := writeFrame(frame); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) framesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	framesTmpl.ExecuteTemplate(w, "camera-frames", s.cameraList(r.Context()))
+}
+
+func (s *Server) sendCameraCommand(w http.ResponseWriter, r *http.Request, cam Camera, msgs ...protobuf.Message) {
+	for _, msg := range msgs {
+		result, err := s.askCommand(r.Context(), cam, msg)
+		if err != nil {
+			log.Printf("Failed to send %T to camera %s: %v", msg, cam.CameraID, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !result.Ok {
+			http.Error(w, result.Error, http.StatusBadRequest)
+			return
+		}
+	}
+	// the camera list refreshes on this event to show the new state
+	w.Header().Set("HX-Trigger", "cameras-changed")
+	s.writeCameraStatus(w, r, cam)
+}
+
+// askCoordinator sends a camera registry command to the SystemCoordinatorActor
+func (s *Server) askCoordinator(ctx context.Context, msg protobuf.Message) (*proto.CommandResult, error) {
+	resp, err := actor.Ask(ctx, s.coordinatorPID, msg, askTimeout)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := resp.(*proto.CommandResult)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T", resp)
+	}
+	return result, nil
+}
+
+// camera looks up the camera of the id path value. It replies with an error
+// and returns false when the camera doesn't exist.
+func (s *Server) camera(w http.ResponseWriter, r *http.Request) (Camera, bool) {
+	id := r.PathValue("id")
+	resp, err := actor.Ask(r.Context(), s.coordinatorPID, &proto.GetCamera{CameraId: id}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get camera %s: %v", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return Camera{}, false
+	}
+	info, ok := resp.(*proto.CameraInfo)
+	if !ok {
+		http.NotFound(w, r)
+		return Camera{}, false
+	}
+	// cameras run as children of the coordinator, which stops them between
+	// a failure and their restart
+	pid, err := s.coordinatorPID.Child(id)
+	if err != nil {
+		if info.Stopped {
+			http.Error(w, fmt.Sprintf("camera stopped after failing %d times: %s", info.Restarts+1, info.LastFailure), http.StatusServiceUnavailable)
+		} else {
+			http.Error(w, "camera is restarting: "+info.LastFailure, http.StatusServiceUnavailable)
+		}
+		return Camera{}, false
+	}
+	return Camera{CameraID: info.CameraId, Source: info.Source, Spec: info.Spec, PID: pid}, true
+}
+
+func (s *Server) askCommand(ctx context.Context, cam Camera, msg protobuf.Message) (*proto.CommandResult, error) {
+	resp, err := actor.Ask(ctx, cam.PID, msg, askTimeout)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := resp.(*proto.CommandResult)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T", resp)
+	}
+	return result, nil
+}
+
+func (s *Server) writeCameraStatus(w http.ResponseWriter, r *http.Request, cam Camera) {
+	status, err := s.cameraStatus(r.Context(), cam)
+	if err != nil {
+		log.Printf("Failed to get status of camera %s: %v", cam.CameraID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeProto(w, status)
+}
+
+// cameraList returns the registered cameras along with their current state
+func (s *Server) cameraList(ctx context.Context) []Camera {
+	resp, err := actor.Ask(ctx, s.coordinatorPID, &proto.ListCameras{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to list cameras: %v", err)
+		return nil
+	}
+	cameras, ok := resp.(*proto.CameraList)
+	if !ok {
+		log.Printf("Failed to list cameras: unexpected response %T", resp)
+		return nil
+	}
+	list := make([]Camera, 0, len(cameras.Cameras))
+	for _, info := range cameras.Cameras {
+		// the list is shown to anyone loading the page, keep the credentials out of it
+		cam := Camera{CameraID: info.CameraId, Source: source.Redact(info.Source), State: "unknown", Restarts: info.Restarts, Failure: info.LastFailure}
+		if info.LastFailureAt != 0 {
+			cam.FailedAt = time.UnixMilli(info.LastFailureAt)
+		}
+		switch {
+		case info.Stopped:
+			cam.State = "stopped"
+		case info.Status != nil:
+			cam.State = stateLabel(info.Status.State)
+			cam.Dropped = info.Status.FramesDropped
+		}
+		list = append(list, cam)
+	}
+	return list
+}
+
+func (s *Server) cameraStatus(ctx context.Context, cam Camera) (*proto.CameraStatus, error) {
+	resp, err := actor.Ask(ctx, cam.PID, &proto.GetCameraStatus{}, askTimeout)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := resp.(*proto.CameraStatus)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T", resp)
+	}
+	return status, nil
+}
+
+// parseCaptureConfig reads the capture settings from the form values fps,
+// resolution ("native" or WIDTHxHEIGHT), keep_aspect, jpeg_quality,
+// max_in_flight and drop_policy ("oldest" or "newest").
+// Missing values are taken from base.
+func parseCaptureConfig(r *http.Request, base *proto.CaptureConfig) (*proto.CaptureConfig, error) {
+	config := &proto.CaptureConfig{
+		Fps:             base.Fps,
+		Width:           base.Width,
+		Height:          base.Height,
+		KeepAspectRatio: base.KeepAspectRatio,
+		JpegQuality:     base.JpegQuality,
+		MaxInFlight:     base.MaxInFlight,
+		DropPolicy:      base.DropPolicy,
+	}
+	if v := r.FormValue("fps"); v != "" {
+		fps, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fps %q", v)
+		}
+		config.Fps = fps
+	}
+	if v := r.FormValue("resolution"); v != "" {
+		if v == "native" {
+			config.Width, config.Height = 0, 0
+		} else {
+			var width, height int32
+			if _, err := fmt.Sscanf(v, "%dx%d", &width, &height); err != nil {
+				return nil, fmt.Errorf("invalid resolution %q, expected native or WIDTHxHEIGHT", v)
+			}
+			config.Width, config.Height = width, height
+		}
+	}
+	if v := r.FormValue("keep_aspect"); v != "" {
+		keep, err := parseFormBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keep_aspect %q", v)
+		}
+		config.KeepAspectRatio = keep
+	}
+	if v := r.FormValue("jpeg_quality"); v != "" {
+		quality, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jpeg_quality %q", v)
+		}
+		config.JpegQuality = int32(quality)
+	}
+	if v := r.FormValue("max_in_flight"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_in_flight %q", v)
+		}
+		config.MaxInFlight = int32(n)
+	}
+	switch v := r.FormValue("drop_policy"); v {
+	case "":
+	case "oldest":
+		config.DropPolicy = proto.FrameDropPolicy_FRAME_DROP_POLICY_DROP_OLDEST
+	case "newest":
+		config.DropPolicy = proto.FrameDropPolicy_FRAME_DROP_POLICY_DROP_NEWEST
+	default:
+		return nil, fmt.Errorf("invalid drop_policy %q, expected oldest or newest", v)
+	}
+	if err := actors.ValidateCaptureConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseProcessingConfig reads the processing settings from the form values
+// detectors (repeated or comma separated), motion, motion_sensitivity,
+// motion_min_area, motion_events, tracking, tracking_iou, tracking_max_distance,
+// tracking_max_missed and tracking_kalman. Missing values are taken from base.
+func (s *Server) parseProcessingConfig(r *http.Request, base *proto.ProcessingConfig) (*proto.ProcessingConfig, error) {
+	detectors := append([]string(nil), base.GetDetectors()...)
+	if values, ok := r.Form["detectors"]; ok {
+		detectors = detectors[:0]
+		for _, v := range values {
+			for _, name := range strings.Split(v, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					detectors = append(detectors, name)
+				}
+			}
+		}
+		if err := s.detectors.Validate(detectors); err != nil {
+			return nil, err
+		}
+	}
+
+	motion := &proto.MotionConfig{}
+	if m := base.GetMotion(); m != nil {
+		motion.Enabled = m.Enabled
+		motion.Sensitivity = m.Sensitivity
+		motion.MinArea = m.MinArea
+		motion.EmitEvents = m.EmitEvents
+	}
+	if v := r.FormValue("motion"); v != "" {
+		enabled, err := parseFormBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid motion %q", v)
+		}
+		motion.Enabled = enabled
+	}
+	if v := r.FormValue("motion_sensitivity"); v != "" {
+		sensitivity, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid motion_sensitivity %q", v)
+		}
+		motion.Sensitivity = float32(sensitivity)
+	}
+	if v := r.FormValue("motion_min_area"); v != "" {
+		area, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid motion_min_area %q", v)
+		}
+		motion.MinArea = int32(area)
+	}
+	if v := r.FormValue("motion_events"); v != "" {
+		emit, err := parseFormBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid motion_events %q", v)
+		}
+		motion.EmitEvents = emit
+	}
+	tracking := &proto.TrackingConfig{}
+	if t := base.GetTracking(); t != nil {
+		tracking.Enabled = t.Enabled
+		tracking.IouThreshold = t.IouThreshold
+		tracking.MaxDistance = t.MaxDistance
+		tracking.MaxMissed = t.MaxMissed
+		tracking.Kalman = t.Kalman
+	}
+	if v := r.FormValue("tracking"); v != "" {
+		enabled, err := parseFormBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracking %q", v)
+		}
+		tracking.Enabled = enabled
+	}
+	if v := r.FormValue("tracking_iou"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracking_iou %q", v)
+		}
+		tracking.IouThreshold = float32(threshold)
+	}
+	if v := r.FormValue("tracking_max_distance"); v != "" {
+		distance, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracking_max_distance %q", v)
+		}
+		tracking.MaxDistance = float32(distance)
+	}
+	if v := r.FormValue("tracking_max_missed"); v != "" {
+		missed, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracking_max_missed %q", v)
+		}
+		tracking.MaxMissed = int32(missed)
+	}
+	if v := r.FormValue("tracking_kalman"); v != "" {
+		kalman, err := parseFormBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracking_kalman %q", v)
+		}
+		tracking.Kalman = kalman
+	}
+
+	// zones and tripwires are edited through their own endpoints
+	config := &proto.ProcessingConfig{
+		Motion:    motion,
+		Detectors: detectors,
+		Zones:     base.GetZones(),
+		Tracking:  tracking,
+		Tripwires: base.GetTripwires(),
+	}
+	if err := actors.ValidateProcessingConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseFormBool parses a boolean form value, "on" being sent by checked checkboxes
+func parseFormBool(v string) (bool, error) {
+	if v == "on" {
+		return true, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// stateLabel turns CAMERA_STATE_STREAMING into streaming
+func stateLabel(state proto.CameraState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "CAMERA_STATE_"))
+}
+
+func writeProto(w http.ResponseWriter, msg protobuf.Message) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
 type clip struct {
 	Filename string `json:"filename"`
 	CameraID string `json:"camera_id"`
 }
 
-func clipsHandler(w http.ResponseWriter, r *http.Request) {
+func (s *Server) clipHandler(w http.ResponseWriter, r *http.Request) {
+	if s.clipsDir == "" {
+		http.NotFound(w, r)
+		return
+	}
+	http.StripPrefix("/clips/", http.FileServer(http.Dir(s.clipsDir))).ServeHTTP(w, r)
+}
+
+func (s *Server) clipsHandler(w http.ResponseWriter, r *http.Request) {
 	files := []clip{}
-	_ = filepath.WalkDir("clips", func(path string, d fs.DirEntry, err error) error {
+	if s.clipsDir == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		clipsListTmpl.ExecuteTemplate(w, "clips-list", files)
+		return
+	}
+	_ = filepath.WalkDir(s.clipsDir, func(path string, d fs.DirEntry, err error) error {
 		if err == nil && !d.IsDir() && (filepath.Ext(path) == ".jpg" || filepath.Ext(path) == ".jpeg") {
 			dir := filepath.Base(filepath.Dir(path))
 			files = append(files, clip{CameraID: dir, Filename: filepath.Join(dir, filepath.Base(path))})
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/tochemey/goakt/v3/actor"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// subscription is the JSON form of a proto.Subscription, events are
+// detection, track, analytics or lifecycle
+type subscription struct {
+	ID            string   `json:"id"`
+	Actor         string   `json:"actor"`
+	Events        []string `json:"events"`
+	Cameras       []string `json:"cameras"`
+	Labels        []string `json:"labels"`
+	MinConfidence float32  `json:"min_confidence"`
+}
+
+// WithEventBus manages the subscriptions of the EventBusActor
+func (s *Server) WithEventBus(pid *actor.PID) *Server {
+	s.busPID = pid
+	return s
+}
+
+func (s *Server) subscriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.busPID == nil {
+		http.Error(w, "event bus is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	resp, err := actor.Ask(r.Context(), s.busPID, &proto.GetSubscriptions{}, askTimeout)
+	if err != nil {
+		log.Printf("Failed to get subscriptions: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subscriptions, ok := resp.(*proto.Subscriptions)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	list := []subscription{}
+	for _, sub := range subscriptions.Subscriptions {
+		events := []string{}
+		for _, kind := range sub.GetFilter().GetKinds() {
+			events = append(events, strings.ToLower(strings.TrimPrefix(kind.String(), "EVENT_KIND_")))
+		}
+		list = append(list, subscription{
+			ID:            sub.Id,
+			Actor:         sub.Actor,
+			Events:        events,
+			Cameras:       append([]string{}, sub.GetFilter().GetCameraIds()...),
+			Labels:        append([]string{}, sub.GetFilter().GetLabels()...),
+			MinConfidence: sub.GetFilter().GetMinConfidence(),
+		})
+	}
+	writeJSON(w, list)
+}
+
+// subscribeHandler adds the subscription in the JSON body, or replaces the one with the same id
+func (s *Server) subscribeHandler(w http.ResponseWriter, r *http.Request) {
+	if s.busPID == nil {
+		http.Error(w, "event bus is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	var body subscription
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid subscription: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Actor == "" {
+		http.Error(w, "actor is required", http.StatusBadRequest)
+		return
+	}
+	filter := &proto.EventFilter{CameraIds: body.Cameras, Labels: body.Labels, MinConfidence: body.MinConfidence}
+	for _, event := range body.Events {
+		kind, ok := proto.EventKind_value["EVENT_KIND_"+strings.ToUpper(event)]
+		if !ok || kind == int32(proto.EventKind_EVENT_KIND_UNSPECIFIED) {
+			http.Error(w, fmt.Sprintf("unknown event %q, expected detection, track, analytics or lifecycle", event), http.StatusBadRequest)
+			return
+		}
+		filter.Kinds = append(filter.Kinds, proto.EventKind(kind))
+	}
+	sub := &proto.Subscription{Id: body.ID, Actor: body.Actor, Filter: filter}
+	if err := actors.ValidateSubscription(sub); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.askBus(w, r, &proto.Subscribe{Subscription: sub})
+}
+
+func (s *Server) unsubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	if s.busPID == nil {
+		http.Error(w, "event bus is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	s.askBus(w, r, &proto.Unsubscribe{Id: r.PathValue("id")})
+}
+
+// askBus sends a subscription command to the bus and replies with no content once applied
+func (s *Server) askBus(w http.ResponseWriter, r *http.Request, msg protobuf.Message) {
+	resp, err := actor.Ask(r.Context(), s.busPID, msg, askTimeout)
+	if err != nil {
+		log.Printf("Failed to update subscriptions: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result, ok := resp.(*proto.CommandResult)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unexpected response %T", resp), http.StatusInternalServerError)
+		return
+	}
+	if !result.Ok {
+		http.Error(w, result.Error, http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
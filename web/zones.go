@@ -0,0 +1,109 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/zaibon/surveilsense/actors"
+	"github.com/zaibon/surveilsense/proto"
+)
+
+// zone is the JSON form of a proto.Zone, points are [x, y] pairs in [0, 1]
+type zone struct {
+	Name   string       `json:"name"`
+	Type   string       `json:"type"` // include or exclude
+	Points [][2]float32 `json:"points"`
+}
+
+func zonesFromProto(zones []*proto.Zone) []zone {
+	out := make([]zone, 0, len(zones))
+	for _, z := range zones {
+		points := make([][2]float32, 0, len(z.Points))
+		for _, p := range z.Points {
+			points = append(points, [2]float32{p.X, p.Y})
+		}
+		out = append(out, zone{
+			Name:   z.Name,
+			Type:   strings.ToLower(strings.TrimPrefix(z.Type.String(), "ZONE_TYPE_")),
+			Points: points,
+		})
+	}
+	return out
+}
+
+func zonesToProto(zones []zone) ([]*proto.Zone, error) {
+	out := make([]*proto.Zone, 0, len(zones))
+	for _, z := range zones {
+		zoneType, ok := proto.ZoneType_value["ZONE_TYPE_"+strings.ToUpper(z.Type)]
+		if !ok {
+			return nil, fmt.Errorf("zone %q has invalid type %q, expected include or exclude", z.Name, z.Type)
+		}
+		points := make([]*proto.Point, 0, len(z.Points))
+		for _, p := range z.Points {
+			points = append(points, &proto.Point{X: p[0], Y: p[1]})
+		}
+		out = append(out, &proto.Zone{Name: z.Name, Type: proto.ZoneType(zoneType), Points: points})
+	}
+	return out, actors.ValidateZones(out)
+}
+
+// zonesPageHandler renders the editor drawing the zones of a camera over its snapshot
+func (s *Server) zonesPageHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zonesTmpl.ExecuteTemplate(w, "zones", cam)
+}
+
+func (s *Server) zonesHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	status, err := s.cameraStatus(r.Context(), cam)
+	if err != nil {
+		log.Printf("Failed to get status of camera %s: %v", cam.CameraID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, zonesFromProto(status.GetProcessing().GetZones()))
+}
+
+// updateZonesHandler replaces the zones of a camera with the JSON list in the body
+func (s *Server) updateZonesHandler(w http.ResponseWriter, r *http.Request) {
+	cam, ok := s.camera(w, r)
+	if !ok {
+		return
+	}
+	var body []zone
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid zones: %v", err), http.StatusBadRequest)
+		return
+	}
+	zones, err := zonesToProto(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.updateProcessing(w, r, cam, func(config *proto.ProcessingConfig) {
+		config.Zones = zones
+	})
+}
+
+// updateProcessing applies change to the saved processing settings of the camera and sends them
+func (s *Server) updateProcessing(w http.ResponseWriter, r *http.Request, cam Camera, change func(config *proto.ProcessingConfig)) {
+	// the spec is shared with the coordinator, work on a copy
+	config := &proto.ProcessingConfig{}
+	if cam.Spec.GetProcessing() != nil {
+		config = protobuf.Clone(cam.Spec.Processing).(*proto.ProcessingConfig)
+	}
+	change(config)
+	s.updateCamera(w, r, &proto.CameraSpec{CameraId: cam.CameraID, Processing: config})
+}